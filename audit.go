@@ -0,0 +1,43 @@
+package deck
+
+import "time"
+
+// ShuffleAlgorithm names the algorithm used by ShuffleWithSeed in audit records
+const ShuffleAlgorithm = "fisher-yates/math-rand"
+
+// AuditRecord is a verifiable record of one seeded shuffle. Given the deck as
+// it was before the shuffle, anyone can replay the seed and check that the
+// result hashes to AfterHash.
+type AuditRecord struct {
+	Seed       int64     `json:"seed"`
+	Algorithm  string    `json:"algorithm"`
+	Timestamp  time.Time `json:"timestamp"`
+	BeforeHash string    `json:"before_hash"`
+	AfterHash  string    `json:"after_hash"`
+}
+
+// ShuffleWithAudit shuffles the deck with ShuffleWithSeed and returns a record
+// of the shuffle, including the deck's Hash before and after
+func (d *Deck) ShuffleWithAudit(seed int64) AuditRecord {
+	record := AuditRecord{
+		Seed:       seed,
+		Algorithm:  ShuffleAlgorithm,
+		Timestamp:  time.Now().UTC(),
+		BeforeHash: d.Hash(),
+	}
+	d.ShuffleWithSeed(seed)
+	record.AfterHash = d.Hash()
+	return record
+}
+
+// Verify replays the recorded shuffle on a copy of before, the deck as it was
+// before shuffling, and reports whether both hashes match the record
+func (r AuditRecord) Verify(before *Deck) bool {
+	if r.Algorithm != ShuffleAlgorithm || before.Hash() != r.BeforeHash {
+		return false
+	}
+
+	replay := NewDeckFromCards(before.cards())
+	replay.ShuffleWithSeed(r.Seed)
+	return replay.Hash() == r.AfterHash
+}
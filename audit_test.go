@@ -0,0 +1,41 @@
+package deck
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestShuffleWithAudit(t *testing.T) {
+	deck := NewDeck()
+	before := NewDeckFromCards(deck.Cards())
+
+	record := deck.ShuffleWithAudit(42)
+	if record.Seed != 42 || record.Algorithm != ShuffleAlgorithm || record.Timestamp.IsZero() {
+		t.Errorf("Unexpected audit record: %+v", record)
+	}
+	if record.BeforeHash != before.Hash() || record.AfterHash != deck.Hash() {
+		t.Error("Expected the record to hold the before and after hashes")
+	}
+	if record.BeforeHash == record.AfterHash {
+		t.Error("Expected shuffling to change the hash")
+	}
+
+	if !record.Verify(before) {
+		t.Error("Expected the record to verify against the original deck")
+	}
+	if record.Verify(deck) {
+		t.Error("Expected the record not to verify against the shuffled deck")
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling record: %v", err)
+	}
+	var decoded AuditRecord
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error unmarshaling record: %v", err)
+	}
+	if !decoded.Verify(before) {
+		t.Error("Expected a decoded record to verify")
+	}
+}
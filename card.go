@@ -1,6 +1,9 @@
 package deck
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Suit represents a playing card suit
 type Suit int
@@ -28,6 +31,11 @@ func (s Suit) String() string {
 	}
 }
 
+// IsValid returns true if the suit is one of the four standard suits
+func (s Suit) IsValid() bool {
+	return s >= Spades && s <= Clubs
+}
+
 // Symbol returns the Unicode symbol for a suit
 func (s Suit) Symbol() string {
 	switch s {
@@ -44,6 +52,36 @@ func (s Suit) Symbol() string {
 	}
 }
 
+// SymbolSet maps each suit to the string used to render it
+type SymbolSet struct {
+	Spades   string
+	Hearts   string
+	Diamonds string
+	Clubs    string
+}
+
+// UnicodeSymbols renders suits with the Unicode symbols used by Suit.Symbol
+var UnicodeSymbols = SymbolSet{Spades: "♠", Hearts: "♥", Diamonds: "♦", Clubs: "♣"}
+
+// ASCIISymbols renders suits as lowercase letters for ASCII-only output
+var ASCIISymbols = SymbolSet{Spades: "s", Hearts: "h", Diamonds: "d", Clubs: "c"}
+
+// Symbol returns the set's symbol for a suit
+func (set SymbolSet) Symbol(s Suit) string {
+	switch s {
+	case Spades:
+		return set.Spades
+	case Hearts:
+		return set.Hearts
+	case Diamonds:
+		return set.Diamonds
+	case Clubs:
+		return set.Clubs
+	default:
+		return "?"
+	}
+}
+
 // Rank represents a playing card rank
 type Rank int
 
@@ -97,6 +135,11 @@ func (r Rank) String() string {
 	}
 }
 
+// IsValid returns true if the rank is between Ace and King
+func (r Rank) IsValid() bool {
+	return r >= Ace && r <= King
+}
+
 // Symbol returns the short symbol representation of a rank
 func (r Rank) Symbol() string {
 	switch r {
@@ -131,6 +174,88 @@ func (r Rank) Symbol() string {
 	}
 }
 
+// compactSymbol returns the rank's symbol using "T" for Ten, so every rank is
+// a single character
+func (r Rank) compactSymbol() string {
+	if r == Ten {
+		return "T"
+	}
+	return r.Symbol()
+}
+
+// aceHighValue returns the rank's numeric value with Ace counted as 14
+func (r Rank) aceHighValue() int {
+	if r == Ace {
+		return 14
+	}
+	return int(r)
+}
+
+// Next returns the rank immediately above r. Ranks never wrap around: with
+// aceHigh false the order is Ace, Two, ..., King and King has no next rank;
+// with aceHigh true the order is Two, ..., King, Ace, so King is followed by
+// Ace and Ace has no next rank. The boolean is false when there is no next rank.
+func (r Rank) Next(aceHigh bool) (Rank, bool) {
+	switch {
+	case r < Ace || r > King:
+		return 0, false
+	case aceHigh && r == King:
+		return Ace, true
+	case aceHigh && r == Ace:
+		return 0, false
+	case r == King:
+		return 0, false
+	default:
+		return r + 1, true
+	}
+}
+
+// Add returns the rank n places above r in Ace-low order, clamped at King, so
+// King.Add(1) is King. A negative n moves down, clamped at Ace. Invalid ranks
+// are returned unchanged. See AddWrap for arithmetic that wraps around.
+func (r Rank) Add(n int) Rank {
+	if !r.IsValid() {
+		return r
+	}
+
+	v := int(r) + n
+	switch {
+	case v < int(Ace):
+		return Ace
+	case v > int(King):
+		return King
+	default:
+		return Rank(v)
+	}
+}
+
+// Sub returns the rank n places below r, clamped at Ace, so Ace.Sub(1) is
+// Ace. It is equivalent to Add(-n).
+func (r Rank) Sub(n int) Rank {
+	return r.Add(-n)
+}
+
+// AddWrap returns the rank n places above r, wrapping from King back round to
+// Ace, so King.AddWrap(1) is Ace. A negative n moves down, wrapping from Ace
+// to King. Invalid ranks are returned unchanged.
+func (r Rank) AddWrap(n int) Rank {
+	if !r.IsValid() {
+		return r
+	}
+
+	v := (int(r) - 1 + n) % 13
+	if v < 0 {
+		v += 13
+	}
+	return Rank(v + 1)
+}
+
+// SubWrap returns the rank n places below r, wrapping from Ace back round to
+// King, so Ace.SubWrap(1) is King. It is equivalent to AddWrap(-n).
+func (r Rank) SubWrap(n int) Rank {
+	return r.AddWrap(-n)
+}
+
 // Card represents a playing card
 type Card struct {
 	Suit Suit
@@ -142,6 +267,12 @@ func NewCard(suit Suit, rank Rank) Card {
 	return Card{Suit: suit, Rank: rank}
 }
 
+// IsValid returns true if the card has a valid suit and rank. The zero Card,
+// as returned alongside errors, is not valid.
+func (c Card) IsValid() bool {
+	return c.Suit.IsValid() && c.Rank.IsValid()
+}
+
 // String returns the full string representation of a card
 func (c Card) String() string {
 	return fmt.Sprintf("%s of %s", c.Rank.String(), c.Suit.String())
@@ -152,6 +283,37 @@ func (c Card) ShortString() string {
 	return fmt.Sprintf("%s%s", c.Rank.Symbol(), c.Suit.Symbol())
 }
 
+// ShortStringWith returns the short representation of a card using the given
+// suit symbols (e.g., "Ah" with ASCIISymbols)
+func (c Card) ShortStringWith(set SymbolSet) string {
+	return c.Rank.Symbol() + set.Symbol(c.Suit)
+}
+
+// ShortStringCompact returns the short representation of a card using "T" for
+// Ten (e.g., "T♥"), so every card is exactly two runes
+func (c Card) ShortStringCompact() string {
+	return c.Rank.compactSymbol() + c.Suit.Symbol()
+}
+
+// NoColor disables ANSI color codes in ColorString output, for example when
+// writing to a file or pipe
+var NoColor = false
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// ColorString returns the short representation of a card wrapped in ANSI
+// color codes, red for Hearts and Diamonds. Black suits use the terminal's
+// default color. If NoColor is set it is identical to ShortString.
+func (c Card) ColorString() string {
+	if NoColor || !c.IsRed() {
+		return c.ShortString()
+	}
+	return ansiRed + c.ShortString() + ansiReset
+}
+
 // IsRed returns true if the card is red (Hearts or Diamonds)
 func (c Card) IsRed() bool {
 	return c.Suit == Hearts || c.Suit == Diamonds
@@ -162,7 +324,150 @@ func (c Card) IsBlack() bool {
 	return c.Suit == Spades || c.Suit == Clubs
 }
 
+// Color represents the color of a playing card suit
+type Color int
+
+const (
+	Red Color = iota + 1
+	Black
+)
+
+// String returns the string representation of a color
+func (c Color) String() string {
+	switch c {
+	case Red:
+		return "Red"
+	case Black:
+		return "Black"
+	default:
+		return "Unknown"
+	}
+}
+
+// Color returns the card's color, or the zero Color for an invalid suit
+func (c Card) Color() Color {
+	switch {
+	case c.IsRed():
+		return Red
+	case c.IsBlack():
+		return Black
+	default:
+		return 0
+	}
+}
+
 // IsFaceCard returns true if the card is a face card (Jack, Queen, King)
 func (c Card) IsFaceCard() bool {
 	return c.Rank == Jack || c.Rank == Queen || c.Rank == King
 }
+
+// IsFaceCardCustom returns true if the card's rank is one of the given ranks,
+// for games that define their own set of court cards, such as counting Ten
+// alongside Jack, Queen and King. IsFaceCard is the usual Jack-Queen-King
+// check.
+func (c Card) IsFaceCardCustom(ranks ...Rank) bool {
+	for _, rank := range ranks {
+		if c.Rank == rank {
+			return true
+		}
+	}
+	return false
+}
+
+// SameSuit returns true if both cards are of the same suit
+func (c Card) SameSuit(other Card) bool {
+	return c.Suit == other.Suit
+}
+
+// SameRank returns true if both cards are of the same rank
+func (c Card) SameRank(other Card) bool {
+	return c.Rank == other.Rank
+}
+
+// SameColor returns true if both cards are the same color. Cards with an
+// invalid suit have no color and never match.
+func (c Card) SameColor(other Card) bool {
+	color := c.Color()
+	return color != 0 && color == other.Color()
+}
+
+// Key returns a canonical index for the card in the range 0-51, computed as
+// suit*13 + (rank-1). Spades occupy 0-12, Hearts 13-25, Diamonds 26-38 and
+// Clubs 39-51, each running Ace to King. The index is stable and suitable for
+// array-based lookups such as [52]T. Key is only defined for valid cards;
+// for others, including the zero Card, the result is meaningless.
+//
+// Card is a comparable struct and may also be used directly as a map key.
+func (c Card) Key() uint8 {
+	return uint8(int(c.Suit)*13 + int(c.Rank) - 1)
+}
+
+// SortKey returns an integer that orders cards by suit, then by rank, the
+// same ordering Deck.Sort uses. It is computed as suit*13 + (rank-1), which for
+// valid cards equals Key, so comparing two keys is a single int comparison.
+func (c Card) SortKey() int {
+	return int(c.Suit)*13 + int(c.Rank) - 1
+}
+
+// CardFromIndex returns the card for a canonical index produced by Key. Only
+// indexes 0-51 name a card; for anything larger it returns the zero Card,
+// which IsValid reports as invalid.
+func CardFromIndex(index uint8) Card {
+	if index > 51 {
+		return Card{}
+	}
+	return NewCard(Suit(index/13), Rank(index%13+1))
+}
+
+// IsAdjacentTo returns true if the two cards are consecutive in rank, in
+// either order, regardless of suit. An Ace is adjacent to both Two (Ace low)
+// and King (Ace high), but King-Ace-Two does not wrap into a run.
+func (c Card) IsAdjacentTo(other Card) bool {
+	for _, aceHigh := range []bool{false, true} {
+		if next, ok := c.Rank.Next(aceHigh); ok && next == other.Rank {
+			return true
+		}
+		if next, ok := other.Rank.Next(aceHigh); ok && next == c.Rank {
+			return true
+		}
+	}
+	return false
+}
+
+// glyphBase is the first code point of the Unicode Playing Cards block. Each
+// suit occupies a row of 16 code points starting at glyphBase+suit*16, with
+// the Ace at offset 1 and the Knight, which has no Rank, at offset 12.
+const glyphBase = 0x1F0A0
+
+// Glyph returns the Unicode playing card character for the card, such as
+// U+1F0A1 for the Ace of Spades. Invalid cards return the card back U+1F0A0.
+func (c Card) Glyph() rune {
+	if !c.IsValid() {
+		return glyphBase
+	}
+
+	offset := rune(c.Rank)
+	if c.Rank >= Queen {
+		offset++ // skip the Knight
+	}
+	return glyphBase + rune(c.Suit)*16 + offset
+}
+
+// ParseGlyph converts a Unicode playing card character back to a Card. It is
+// the inverse of Glyph; Knights, jokers and the card back are rejected.
+func ParseGlyph(r rune) (Card, error) {
+	if r < glyphBase || r >= glyphBase+4*16 {
+		return Card{}, errors.New("not a playing card glyph")
+	}
+
+	suit := Suit((r - glyphBase) / 16)
+	offset := int((r - glyphBase) % 16)
+	switch {
+	case offset >= 1 && offset <= 11:
+		return NewCard(suit, Rank(offset)), nil
+	case offset == 13 || offset == 14:
+		return NewCard(suit, Rank(offset-1)), nil
+	default:
+		return Card{}, errors.New("glyph has no matching card")
+	}
+}
@@ -0,0 +1,128 @@
+package deck
+
+import "errors"
+
+// MaxPermutationSize is the largest deck Permutations will enumerate. 12!
+// is already about 479 million orderings.
+const MaxPermutationSize = 12
+
+// Combinations returns every k-card subset of the deck, each in deck order.
+// There are C(n, k) of them for a deck of n cards, which grows very quickly;
+// prefer CombinationsFunc for large decks or k. It returns nil if k is
+// negative or larger than the deck.
+func (d *Deck) Combinations(k int) [][]Card {
+	if k < 0 || k > d.Size() {
+		return nil
+	}
+
+	combos := make([][]Card, 0, binomial(d.Size(), k, 1<<20))
+	d.CombinationsFunc(k, func(combo []Card) bool {
+		c := make([]Card, k)
+		copy(c, combo)
+		combos = append(combos, c)
+		return true
+	})
+	return combos
+}
+
+// CombinationsFunc calls fn with each of the C(n, k) k-card subsets of the
+// deck without materializing them all. The slice passed to fn is reused
+// between calls and must be copied if retained. Returning false from fn stops
+// the enumeration early.
+func (d *Deck) CombinationsFunc(k int, fn func([]Card) bool) {
+	forEachCombination(d.cards(), k, fn)
+}
+
+// Permutations calls fn with every ordering of the deck's cards, using Heap's
+// algorithm. A deck of n cards has n! orderings, so this is only practical for
+// small decks; rather than run effectively forever it returns an error for
+// decks larger than MaxPermutationSize. The slice passed to fn is reused
+// between calls and must be copied if retained. Returning false from fn stops
+// the enumeration early.
+func (d *Deck) Permutations(fn func([]Card) bool) error {
+	n := d.Size()
+	if n > MaxPermutationSize {
+		return errors.New("deck too large to enumerate permutations")
+	}
+
+	perm := d.Cards()
+	if !fn(perm) {
+		return nil
+	}
+
+	counters := make([]int, n)
+	for i := 1; i < n; {
+		if counters[i] < i {
+			if i%2 == 0 {
+				perm[0], perm[i] = perm[i], perm[0]
+			} else {
+				perm[counters[i]], perm[i] = perm[i], perm[counters[i]]
+			}
+			if !fn(perm) {
+				return nil
+			}
+			counters[i]++
+			i = 1
+		} else {
+			counters[i] = 0
+			i++
+		}
+	}
+	return nil
+}
+
+// forEachCombination calls fn with every k-card combination of cards, in
+// lexicographic order of position. The slice passed to fn is reused between
+// calls. Enumeration stops early if fn returns false.
+func forEachCombination(cards []Card, k int, fn func([]Card) bool) {
+	n := len(cards)
+	if k < 0 || k > n {
+		return
+	}
+
+	indices := make([]int, k)
+	combo := make([]Card, k)
+	for i := range indices {
+		indices[i] = i
+		combo[i] = cards[i]
+	}
+
+	for {
+		if !fn(combo) {
+			return
+		}
+
+		// Find the rightmost index that can still be advanced
+		i := k - 1
+		for i >= 0 && indices[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+
+		indices[i]++
+		combo[i] = cards[indices[i]]
+		for j := i + 1; j < k; j++ {
+			indices[j] = indices[j-1] + 1
+			combo[j] = cards[indices[j]]
+		}
+	}
+}
+
+// binomial returns n choose k, saturating at limit to avoid overflow
+func binomial(n, k, limit int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	k = min(k, n-k)
+
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+		if result > limit {
+			return limit
+		}
+	}
+	return result
+}
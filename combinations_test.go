@@ -0,0 +1,96 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestCombinations(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Ace), NewCard(Hearts, Two), NewCard(Clubs, Three), NewCard(Diamonds, Four),
+	})
+
+	combos := deck.Combinations(2)
+	if len(combos) != 6 {
+		t.Fatalf("Expected C(4,2) = 6 combinations, got %d", len(combos))
+	}
+
+	seen := make(map[[2]Card]bool)
+	for _, combo := range combos {
+		key := [2]Card{combo[0], combo[1]}
+		if seen[key] {
+			t.Errorf("Duplicate combination %v", combo)
+		}
+		seen[key] = true
+	}
+
+	if len(deck.Combinations(0)) != 1 {
+		t.Error("Expected exactly one empty combination")
+	}
+
+	if deck.Combinations(5) != nil {
+		t.Error("Expected nil when k exceeds deck size")
+	}
+}
+
+func TestCombinationsFunc(t *testing.T) {
+	count := 0
+	NewDeck().CombinationsFunc(5, func(hand []Card) bool {
+		count++
+		return true
+	})
+	if count != 2598960 {
+		t.Errorf("Expected C(52,5) = 2598960 combinations, got %d", count)
+	}
+
+	// Stop early
+	count = 0
+	NewDeck().CombinationsFunc(3, func(hand []Card) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("Expected enumeration to stop after 10 calls, got %d", count)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Ace), NewCard(Hearts, Two), NewCard(Clubs, Three), NewCard(Diamonds, Four),
+	})
+
+	seen := make(map[[4]Card]bool)
+	err := deck.Permutations(func(perm []Card) bool {
+		seen[[4]Card(perm)] = true
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error enumerating permutations: %v", err)
+	}
+	if len(seen) != 24 {
+		t.Errorf("Expected 4! = 24 distinct permutations, got %d", len(seen))
+	}
+
+	// Stop early
+	count := 0
+	deck.Permutations(func(perm []Card) bool {
+		count++
+		return count < 5
+	})
+	if count != 5 {
+		t.Errorf("Expected enumeration to stop after 5 calls, got %d", count)
+	}
+
+	if err := NewDeck().Permutations(func([]Card) bool { return true }); err == nil {
+		t.Error("Expected error for a deck larger than MaxPermutationSize")
+	}
+
+	// Empty deck has exactly one (empty) ordering
+	count = 0
+	NewEmptyDeck().Permutations(func(perm []Card) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Errorf("Expected one ordering of an empty deck, got %d", count)
+	}
+}
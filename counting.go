@@ -0,0 +1,27 @@
+package deck
+
+// CountSystem maps each rank to its value in a blackjack card-counting system.
+// Ranks missing from the map count as zero.
+type CountSystem map[Rank]int
+
+// HiLo is the Hi-Lo counting system: Two through Six count +1, Seven through
+// Nine count 0, and Tens, face cards and Aces count -1
+var HiLo = CountSystem{
+	Two: 1, Three: 1, Four: 1, Five: 1, Six: 1,
+	Ten: -1, Jack: -1, Queen: -1, King: -1, Ace: -1,
+}
+
+// RunningCount applies a counting system to the dealt history and returns the
+// running count. A nil system uses HiLo. Dealt history must be enabled with
+// EnableDealtHistory for cards to be counted.
+func (d *Deck) RunningCount(system CountSystem) int {
+	if system == nil {
+		system = HiLo
+	}
+
+	count := 0
+	for _, card := range d.history {
+		count += system[card.Rank]
+	}
+	return count
+}
@@ -0,0 +1,47 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestRunningCount(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Hearts, Two),
+		NewCard(Spades, Five),
+		NewCard(Clubs, Eight),
+		NewCard(Diamonds, King),
+		NewCard(Hearts, Three),
+	})
+	deck.EnableDealtHistory()
+
+	if count := deck.RunningCount(nil); count != 0 {
+		t.Errorf("Expected running count 0 before dealing, got %d", count)
+	}
+
+	deck.DealN(5)
+	// +1 +1 0 -1 +1
+	if count := deck.RunningCount(nil); count != 2 {
+		t.Errorf("Expected Hi-Lo running count 2, got %d", count)
+	}
+
+	custom := CountSystem{Eight: 5}
+	if count := deck.RunningCount(custom); count != 5 {
+		t.Errorf("Expected custom running count 5, got %d", count)
+	}
+
+	deck.Reset()
+	if count := deck.RunningCount(HiLo); count != 0 {
+		t.Errorf("Expected running count 0 after reset, got %d", count)
+	}
+}
+
+func TestHiLoBalanced(t *testing.T) {
+	// Hi-Lo is a balanced count: a full deck sums to zero
+	deck := NewDeck()
+	deck.EnableDealtHistory()
+	deck.DealN(52)
+
+	if count := deck.RunningCount(HiLo); count != 0 {
+		t.Errorf("Expected a full deck to count 0, got %d", count)
+	}
+}
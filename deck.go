@@ -1,75 +1,438 @@
 package deck
 
 import (
+	"container/heap"
+	"context"
 	"errors"
+	"io"
+	"math"
 	"math/rand"
-	"time"
+	"sort"
+	"strings"
 )
 
-// Deck represents a deck of playing cards
+// Deck represents a deck of playing cards.
+//
+// Slices returned by Deck methods never alias the deck's storage or each
+// other's spare capacity: callers may modify or append to them freely without
+// affecting the deck. Slices passed in are likewise copied. The one exception
+// is View, which exists to avoid that copy.
 type Deck struct {
-	cards []Card
+	// buf holds the deck's storage. The cards still in the deck are buf[top:],
+	// top card first; dealing advances top instead of re-slicing, so the
+	// storage can be reclaimed by later additions and reused by Reset.
+	buf []Card
+	top int
+
+	trackHistory bool
+	history      []Card
+
+	trackSeeds bool
+	seeds      []int64
+
+	index map[Card]int
+
+	setAside []Card
+
+	dead []Card
+
+	reshuffles int
 }
 
 // NewDeck creates a new standard 52-card deck
 func NewDeck() *Deck {
-	cards := make([]Card, 0, 52)
+	return &Deck{buf: appendStandard(make([]Card, 0, 52))}
+}
+
+// appendStandard appends the 52 standard cards, in new-deck order, to dst
+func appendStandard(dst []Card) []Card {
 	suits := []Suit{Spades, Hearts, Diamonds, Clubs}
 	ranks := []Rank{Ace, Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Queen, King}
 
 	for _, suit := range suits {
 		for _, rank := range ranks {
-			cards = append(cards, NewCard(suit, rank))
+			dst = append(dst, NewCard(suit, rank))
 		}
 	}
-
-	return &Deck{cards: cards}
+	return dst
 }
 
 // NewEmptyDeck creates a new empty deck
 func NewEmptyDeck() *Deck {
-	return &Deck{cards: make([]Card, 0)}
+	return &Deck{buf: make([]Card, 0)}
 }
 
 // NewDeckFromCards creates a new deck from a slice of cards
 func NewDeckFromCards(cards []Card) *Deck {
 	deckCards := make([]Card, len(cards))
 	copy(deckCards, cards)
-	return &Deck{cards: deckCards}
+	return &Deck{buf: deckCards}
+}
+
+// NewDeckExcluding creates a standard 52-card deck, in NewDeck order, without
+// the given cards. Cards that are not in a standard deck, including invalid
+// cards, are ignored, as are repeats in exclude.
+func NewDeckExcluding(exclude ...Card) *Deck {
+	excluded := cardCounts(exclude)
+	cards := make([]Card, 0, 52)
+	for _, card := range appendStandard(nil) {
+		if excluded[card] == 0 {
+			cards = append(cards, card)
+		}
+	}
+	return &Deck{buf: cards}
+}
+
+// Spanish and Italian suits mapped onto the standard suits
+const (
+	Coins  = Diamonds // Oros / Denari
+	Cups   = Hearts   // Copas / Coppe
+	Swords = Spades   // Espadas / Spade
+	Batons = Clubs    // Bastos / Bastoni
+)
+
+// spanishOmitted holds the ranks removed from a standard deck to build the
+// 40-card Spanish and Italian deck
+var spanishOmitted = [...]Rank{Eight, Nine, Ten}
+
+// SpanishOmittedRanks returns the ranks removed from a standard deck to build
+// the 40-card Spanish and Italian deck: Eight, Nine and Ten. The slice is a
+// fresh copy, so changing it does not affect NewSpanishDeck.
+func SpanishOmittedRanks() []Rank {
+	return append([]Rank(nil), spanishOmitted[:]...)
+}
+
+// NewSpanishDeck creates a 40-card deck as used for Briscola, Scopa and
+// similar games: Ace through Seven plus Jack, Queen and King in each suit,
+// omitting the ranks in SpanishOmittedRanks. The Coins, Cups, Swords and
+// Batons suits map to Diamonds, Hearts, Spades and Clubs.
+func NewSpanishDeck() *Deck {
+	return NewDeck().Filter(func(c Card) bool {
+		for _, rank := range spanishOmitted {
+			if c.Rank == rank {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// NewDeckRange creates a deck holding every rank from minRank to maxRank,
+// inclusive, in each of the given suits, in the order the suits are listed.
+// With no suits it uses all four. An error is returned if a rank or suit is
+// invalid or minRank is greater than maxRank.
+func NewDeckRange(minRank, maxRank Rank, suits ...Suit) (*Deck, error) {
+	if !minRank.IsValid() || !maxRank.IsValid() {
+		return nil, errors.New("invalid rank")
+	}
+	if minRank > maxRank {
+		return nil, errors.New("minimum rank is greater than maximum rank")
+	}
+	if len(suits) == 0 {
+		suits = []Suit{Spades, Hearts, Diamonds, Clubs}
+	}
+
+	cards := make([]Card, 0, len(suits)*int(maxRank-minRank+1))
+	for _, suit := range suits {
+		if !suit.IsValid() {
+			return nil, errors.New("invalid suit")
+		}
+		for rank := minRank; rank <= maxRank; rank++ {
+			cards = append(cards, NewCard(suit, rank))
+		}
+	}
+	return &Deck{buf: cards}, nil
 }
 
 // Size returns the number of cards in the deck
 func (d *Deck) Size() int {
-	return len(d.cards)
+	return len(d.buf) - d.top
+}
+
+// Cap returns the number of cards the deck can hold before its storage must
+// be reallocated. Space freed by dealing is reclaimed, so dealing never
+// reduces Cap.
+func (d *Deck) Cap() int {
+	return cap(d.buf)
 }
 
 // IsEmpty returns true if the deck has no cards
 func (d *Deck) IsEmpty() bool {
-	return len(d.cards) == 0
+	return d.Size() == 0
+}
+
+// cards returns the cards still in the deck, top first. The slice aliases the
+// deck's storage.
+func (d *Deck) cards() []Card {
+	return d.buf[d.top:]
+}
+
+// setCards replaces the deck's storage, making every card in cards live
+func (d *Deck) setCards(cards []Card) {
+	d.buf = cards
+	d.top = 0
+}
+
+// reserve makes room for n more cards at the bottom of the deck, reclaiming
+// the space left by dealt cards before the storage has to grow
+func (d *Deck) reserve(n int) {
+	if d.top > 0 && len(d.buf)+n > cap(d.buf) {
+		live := copy(d.buf, d.buf[d.top:])
+		d.buf = d.buf[:live]
+		d.top = 0
+	}
+}
+
+// removeAt removes the card at position i (0 = top), keeping the order of the
+// rest
+func (d *Deck) removeAt(i int) {
+	cards := d.cards()
+	copy(cards[i:], cards[i+1:])
+	d.buf = d.buf[:len(d.buf)-1]
 }
 
 // Cards returns a copy of the cards in the deck
 func (d *Deck) Cards() []Card {
-	cards := make([]Card, len(d.cards))
-	copy(cards, d.cards)
+	cards := make([]Card, d.Size())
+	copy(cards, d.cards())
 	return cards
 }
 
-// Shuffle shuffles the deck using Fisher-Yates algorithm
-func (d *Deck) Shuffle() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for i := len(d.cards) - 1; i > 0; i-- {
-		j := r.Intn(i + 1)
-		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+// View returns the deck's own cards, top first, without copying. It is meant
+// for hot read-only paths where profiling shows the copy made by Cards to be a
+// bottleneck.
+//
+// The returned slice aliases the deck's storage and must be treated as
+// read-only: writing to it corrupts the deck, bypassing the index and dealt
+// history. It is only valid until the deck is next modified, after which it
+// may show stale or reused cards. Use Cards unless you need this.
+func (d *Deck) View() []Card {
+	return d.buf[d.top:len(d.buf):len(d.buf)]
+}
+
+// Iter returns an immutable snapshot of the cards in the deck, top first, for
+// ranging over. The snapshot is an independent copy, so iterating it remains
+// safe however the deck is mutated afterwards, including by other goroutines.
+// Taking the snapshot itself reads the deck, so it must not race with a
+// mutation; the copy is the price paid for that safety.
+func (d *Deck) Iter() []Card {
+	return d.Cards()
+}
+
+// Channel sends a snapshot of the cards in the deck, top first, on an
+// unbuffered channel for pipeline processing. The cards are copied before
+// Channel returns, so later changes to the deck are not seen, and nothing is
+// dealt or removed. The channel belongs to the sender: it is closed once every
+// card has been sent or ctx is canceled, whichever comes first. Receivers
+// that stop early must cancel ctx so the sending goroutine can exit.
+func (d *Deck) Channel(ctx context.Context) <-chan Card {
+	cards := d.Cards()
+	ch := make(chan Card)
+	go func() {
+		defer close(ch)
+		for _, card := range cards {
+			select {
+			case ch <- card:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// ColorString returns the cards in the deck as space-separated colored short
+// strings (see Card.ColorString)
+func (d *Deck) ColorString() string {
+	parts := make([]string, d.Size())
+	for i, card := range d.cards() {
+		parts[i] = card.ColorString()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Lines returns the cards in the deck as short strings, one per element.
+// The line-per-card form diffs cleanly in golden-file tests.
+func (d *Deck) Lines() []string {
+	lines := make([]string, d.Size())
+	for i, card := range d.cards() {
+		lines[i] = card.ShortString()
 	}
+	return lines
+}
+
+// Intner is a source of random integers. Intn returns a number in [0, n) and
+// is only called with n > 0. *rand.Rand satisfies it.
+type Intner interface {
+	Intn(n int) int
+}
+
+// globalRand draws from the package-level math/rand source, which is seeded
+// randomly at startup and safe for concurrent use
+type globalRand struct{}
+
+// Intn returns a random number in [0, n) from the package-level source
+func (globalRand) Intn(n int) int {
+	return rand.Intn(n)
+}
+
+// Uint64 returns 64 random bits from the package-level source
+func (globalRand) Uint64() uint64 {
+	return rand.Uint64()
+}
+
+// SortedSource is an Intner for tests that always returns n-1. Passed to
+// ShuffleWithRand it makes every Fisher-Yates swap a no-op, so a "shuffled"
+// deck keeps its exact order. It is not random and is meant for testing only.
+type SortedSource struct{}
+
+// Intn returns n-1, the largest value in [0, n)
+func (SortedSource) Intn(n int) int {
+	return n - 1
+}
+
+// Shuffle shuffles the deck using Fisher-Yates algorithm. It draws from the
+// package-level random source and works in place, so it allocates nothing
+// and needs no memory beyond the deck itself, however large the shoe.
+func (d *Deck) Shuffle() {
+	d.ShuffleWithRand(globalRand{})
 }
 
 // ShuffleWithSeed shuffles the deck with a specific seed for reproducible results
 func (d *Deck) ShuffleWithSeed(seed int64) {
-	r := rand.New(rand.NewSource(seed))
-	for i := len(d.cards) - 1; i > 0; i-- {
+	if d.trackSeeds {
+		d.seeds = append(d.seeds, seed)
+	}
+	d.ShuffleWithRand(rand.New(rand.NewSource(seed)))
+}
+
+// ShuffleWithRand shuffles the deck using Fisher-Yates, drawing random
+// numbers from r. Passing a seeded *rand.Rand makes a run of shuffles
+// reproducible without reseeding each time.
+func (d *Deck) ShuffleWithRand(r Intner) {
+	cards := d.cards()
+	for i := len(cards) - 1; i > 0; i-- {
 		j := r.Intn(i + 1)
-		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+}
+
+// shuffleCheckInterval is how many swaps ShuffleContext makes between checks
+// for cancelation
+const shuffleCheckInterval = 4096
+
+// ShuffleContext shuffles the deck like Shuffle, checking ctx for
+// cancelation before it starts and then every 4096 swaps, so it is only worth
+// using for very large shoes. If ctx is canceled it stops and returns
+// ctx.Err(), leaving the deck partly shuffled: still a permutation of the
+// same cards, but not a uniform one. Once every swap is made it returns nil,
+// even if ctx has been canceled since the last check. A deck of fewer than two
+// cards needs no swaps, so it always returns nil.
+func (d *Deck) ShuffleContext(ctx context.Context) error {
+	cards := d.cards()
+	for i := len(cards) - 1; i > 0; i-- {
+		if (len(cards)-1-i)%shuffleCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		j := rand.Intn(i + 1)
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+	return nil
+}
+
+// ShuffleUnbiased shuffles the deck using Fisher-Yates with explicit
+// rejection sampling, so no ordering is favored by modulo bias, however large
+// the deck. Each swap index is drawn from 64 bits of the package-level random
+// source, discarding draws from the incomplete final range that would
+// otherwise favor low indices. Like Shuffle it allocates nothing. The
+// orderings it can reach are still limited by the state of the source, which
+// is far smaller than 52!, so it is not suitable where the order must be
+// unpredictable to an adversary.
+//
+// Shuffle and ShuffleWithSeed are also unbiased, since math/rand's Intn
+// rejection-samples internally; this variant makes the guarantee explicit and
+// independent of that implementation detail.
+func (d *Deck) ShuffleUnbiased() {
+	d.shuffleUnbiased(globalRand{})
+}
+
+// uint64er is a source of 64 random bits. *rand.Rand and globalRand satisfy it.
+type uint64er interface {
+	Uint64() uint64
+}
+
+// shuffleUnbiased performs an explicitly rejection-sampled Fisher-Yates
+// shuffle using r
+func (d *Deck) shuffleUnbiased(r uint64er) {
+	cards := d.cards()
+	for i := len(cards) - 1; i > 0; i-- {
+		j := unbiasedIntn(r, uint64(i+1))
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+}
+
+// unbiasedIntn returns a uniform random number in [0, n) from r. Draws below
+// 2^64 mod n are rejected so the remaining range is an exact multiple of n.
+func unbiasedIntn(r uint64er, n uint64) uint64 {
+	threshold := -n % n
+	for {
+		if v := r.Uint64(); v >= threshold {
+			return v % n
+		}
+	}
+}
+
+// RecommendedShuffles returns the number of riffle shuffles needed to mix a
+// deck of the current size, 7 for 52 cards. Bayer and Diaconis showed that k
+// riffles of n cards leave a total variation distance from uniform of about
+// 1 - 2*Phi(-2^-c / (4*sqrt(3))), where k = 1.5*log2(n) + c. The distance
+// falls below one half once c exceeds -2.224, so the result is the smallest
+// k above 1.5*log2(n) - 2.224, and at least one for two or more cards. A deck
+// of zero or one card needs no shuffling.
+func (d *Deck) RecommendedShuffles() int {
+	n := d.Size()
+	if n < 2 {
+		return 0
+	}
+
+	k := int(math.Ceil(1.5*math.Log2(float64(n)) - 2.224))
+	if k < 1 {
+		return 1
+	}
+	return k
+}
+
+// Cut moves the top position cards to the bottom of the deck, keeping their
+// order, so the card at position becomes the new top card. Position must be
+// between 0 and Size(); cutting at either end leaves the deck unchanged.
+func (d *Deck) Cut(position int) error {
+	if position < 0 || position > d.Size() {
+		return errors.New("cut position out of range")
+	}
+
+	cards := d.cards()
+	reverse(cards[:position])
+	reverse(cards[position:])
+	reverse(cards)
+	return nil
+}
+
+// CutToCard cuts the deck so that the first occurrence of card ends up on top
+func (d *Deck) CutToCard(card Card) error {
+	for i, c := range d.cards() {
+		if c == card {
+			return d.Cut(i)
+		}
+	}
+	return errors.New("card not found in deck")
+}
+
+// reverse reverses cards in place
+func reverse(cards []Card) {
+	for i, j := 0, len(cards)-1; i < j; i, j = i+1, j-1 {
+		cards[i], cards[j] = cards[j], cards[i]
 	}
 }
 
@@ -79,53 +442,582 @@ func (d *Deck) Deal() (Card, error) {
 		return Card{}, errors.New("cannot deal from empty deck")
 	}
 
-	card := d.cards[0]
-	d.cards = d.cards[1:]
+	card := d.buf[d.top]
+	d.top++
+	d.dealt(card)
 	return card, nil
 }
 
+// DealOrReshuffle deals one card from the top of the deck. If the deck is
+// empty, the cards in discard are first moved into it and shuffled, leaving
+// discard empty. An error is returned only if both piles are empty.
+func (d *Deck) DealOrReshuffle(discard *Deck) (Card, error) {
+	if d.IsEmpty() && discard != nil && !discard.IsEmpty() {
+		cards := discard.Cards()
+		discard.unindex(cards...)
+		discard.setCards(discard.buf[:0])
+
+		d.AddCards(cards)
+		d.Shuffle()
+		d.reshuffles++
+	}
+	return d.Deal()
+}
+
+// Reshuffles returns how many times DealOrReshuffle has shuffled a discard
+// pile back into the deck since the last Reset or Clear
+func (d *Deck) Reshuffles() int {
+	return d.reshuffles
+}
+
+// TryDeal deals one card from the top of the deck, reporting false instead of
+// returning an error when the deck is empty
+func (d *Deck) TryDeal() (Card, bool) {
+	if d.IsEmpty() {
+		return Card{}, false
+	}
+
+	card := d.buf[d.top]
+	d.top++
+	d.dealt(card)
+	return card, true
+}
+
 // DealN deals n cards from the top of the deck
 func (d *Deck) DealN(n int) ([]Card, error) {
 	if n < 0 {
 		return nil, errors.New("cannot deal negative number of cards")
 	}
-	if n > len(d.cards) {
+	if n > d.Size() {
 		return nil, errors.New("not enough cards in deck")
 	}
 
 	cards := make([]Card, n)
-	copy(cards, d.cards[:n])
-	d.cards = d.cards[n:]
+	copy(cards, d.cards()[:n])
+	d.top += n
+	d.dealt(cards...)
 	return cards, nil
 }
 
+// DealInto deals cards from the top of the deck into dst, reusing the
+// caller's storage so no allocation is needed. It deals min(len(dst), Size())
+// cards and returns how many; running short of cards is not an error, and an
+// empty deck deals 0, so a refill loop can stop when n is 0. The error is
+// always nil.
+func (d *Deck) DealInto(dst []Card) (int, error) {
+	n := copy(dst, d.cards())
+	d.top += n
+	d.dealt(dst[:n]...)
+	return n, nil
+}
+
+// DealBest looks at the top n cards and deals the best of them according to
+// better, which reports whether a beats b. The other cards stay in place. On a
+// tie the card nearest the top is dealt.
+func (d *Deck) DealBest(n int, better func(a, b Card) bool) (Card, error) {
+	if n <= 0 {
+		return Card{}, errors.New("must look at a positive number of cards")
+	}
+	if n > d.Size() {
+		return Card{}, errors.New("not enough cards in deck")
+	}
+
+	cards := d.cards()
+	best := 0
+	for i := 1; i < n; i++ {
+		if better(cards[i], cards[best]) {
+			best = i
+		}
+	}
+
+	card := cards[best]
+	d.removeAt(best)
+	d.dealt(card)
+	return card, nil
+}
+
+// TopK returns copies of the k best cards in the deck according to better,
+// which reports whether a beats b, best first. Cards that tie keep their deck
+// order. It keeps a heap of the best k seen so far rather than sorting the
+// whole deck, running in O(n log k). The deck is not modified.
+func (d *Deck) TopK(k int, better func(a, b Card) bool) ([]Card, error) {
+	if k < 0 {
+		return nil, errors.New("k cannot be negative")
+	}
+	if k > d.Size() {
+		return nil, errors.New("not enough cards in deck")
+	}
+
+	h := &topKHeap{cards: d.cards(), better: better}
+	for i := range h.cards {
+		switch {
+		case len(h.indices) < k:
+			heap.Push(h, i)
+		case k > 0 && h.worse(h.indices[0], i):
+			h.indices[0] = i
+			heap.Fix(h, 0)
+		}
+	}
+
+	top := make([]Card, len(h.indices))
+	for i := len(top) - 1; i >= 0; i-- {
+		top[i] = h.cards[heap.Pop(h).(int)]
+	}
+	return top, nil
+}
+
+// topKHeap is a heap of card positions with the worst card at the root
+type topKHeap struct {
+	cards   []Card
+	indices []int
+	better  func(a, b Card) bool
+}
+
+// worse reports whether the card at position i ranks below the card at
+// position j, with later positions losing ties
+func (h *topKHeap) worse(i, j int) bool {
+	if h.better(h.cards[j], h.cards[i]) {
+		return true
+	}
+	return !h.better(h.cards[i], h.cards[j]) && i > j
+}
+
+func (h *topKHeap) Len() int           { return len(h.indices) }
+func (h *topKHeap) Less(i, j int) bool { return h.worse(h.indices[i], h.indices[j]) }
+func (h *topKHeap) Swap(i, j int)      { h.indices[i], h.indices[j] = h.indices[j], h.indices[i] }
+func (h *topKHeap) Push(x any)         { h.indices = append(h.indices, x.(int)) }
+
+func (h *topKHeap) Pop() any {
+	last := h.indices[len(h.indices)-1]
+	h.indices = h.indices[:len(h.indices)-1]
+	return last
+}
+
+// DealMatching deals from the top of the deck until it has collected n cards
+// matching the predicate. Non-matching cards passed over on the way are moved,
+// in order, to the set-aside pile (see SetAside). The predicate is called
+// exactly once for each card passed over or dealt, so it may keep state. If
+// the deck holds fewer than n matching cards an error is returned and the
+// deck is left unchanged.
+func (d *Deck) DealMatching(n int, predicate func(Card) bool) ([]Card, error) {
+	if n < 0 {
+		return nil, errors.New("cannot deal negative number of cards")
+	}
+
+	cards := d.cards()
+	matched := make([]Card, 0, n)
+	var skipped []Card
+	end := 0
+	for end < len(cards) && len(matched) < n {
+		if predicate(cards[end]) {
+			matched = append(matched, cards[end])
+		} else {
+			skipped = append(skipped, cards[end])
+		}
+		end++
+	}
+	if len(matched) < n {
+		return nil, errors.New("not enough matching cards in deck")
+	}
+
+	d.setAside = append(d.setAside, skipped...)
+	d.unindex(skipped...)
+	d.top += end
+	d.dealt(matched...)
+	return matched, nil
+}
+
+// SetAside returns the cards skipped by DealMatching since the last Reset or
+// Clear, in the order they were set aside
+func (d *Deck) SetAside() []Card {
+	cards := make([]Card, len(d.setAside))
+	copy(cards, d.setAside)
+	return cards
+}
+
+// DealToWriter deals up to n cards from the top of the deck, writing each
+// card's short string to w on its own line. If the deck runs out it stops
+// without error. It returns the number of cards dealt and written; on a write
+// error the card being written has already been dealt but is not counted.
+func (d *Deck) DealToWriter(w io.Writer, n int) (int, error) {
+	if n < 0 {
+		return 0, errors.New("cannot deal negative number of cards")
+	}
+
+	for i := 0; i < n; i++ {
+		card, ok := d.TryDeal()
+		if !ok {
+			return i, nil
+		}
+		if _, err := io.WriteString(w, card.ShortString()+"\n"); err != nil {
+			return i, err
+		}
+	}
+	return n, nil
+}
+
+// RandomHand removes n randomly chosen cards from the deck and returns them as
+// a new deck. Unlike DealN the cards are not taken from the top, and the
+// remaining cards keep their relative order.
+func (d *Deck) RandomHand(n int) (*Deck, error) {
+	if n < 0 {
+		return nil, errors.New("cannot deal negative number of cards")
+	}
+	if n > d.Size() {
+		return nil, errors.New("not enough cards in deck")
+	}
+
+	cards := make([]Card, n)
+	for i := range cards {
+		j := rand.Intn(d.Size())
+		cards[i] = d.buf[d.top+j]
+		d.removeAt(j)
+	}
+	d.dealt(cards...)
+	return &Deck{buf: cards}, nil
+}
+
+// DealRandomN removes and returns n cards chosen uniformly at random from
+// anywhere in the deck. It runs a partial Fisher-Yates shuffle over only the
+// first n positions, so it costs O(n) rather than shuffling the whole deck.
+// The order of the cards left in the deck is not preserved.
+func (d *Deck) DealRandomN(n int) ([]Card, error) {
+	if n < 0 {
+		return nil, errors.New("cannot deal negative number of cards")
+	}
+	if n > d.Size() {
+		return nil, errors.New("not enough cards in deck")
+	}
+
+	cards := d.cards()
+	for i := 0; i < n; i++ {
+		j := i + rand.Intn(len(cards)-i)
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+
+	dealt := make([]Card, n)
+	copy(dealt, cards[:n])
+	d.top += n
+	d.dealt(dealt...)
+	return dealt, nil
+}
+
+// DealRecencyWeighted removes and returns one card chosen at random with a
+// bias toward the bottom of the deck, where cards added most recently sit.
+// The bottom card has weight 1 and each card's weight halves for every
+// halfLife positions it lies above the bottom, so with a halfLife of 5 the
+// card five from the bottom is half as likely as the bottom card.
+func (d *Deck) DealRecencyWeighted(halfLife int) (Card, error) {
+	if halfLife <= 0 {
+		return Card{}, errors.New("half-life must be positive")
+	}
+
+	last := d.Size() - 1
+	return d.dealWeighted(func(i int) float64 {
+		return math.Exp2(-float64(last-i) / float64(halfLife))
+	})
+}
+
+// dealWeighted removes and returns one card, choosing position i (0 = top)
+// with probability proportional to weight(i)
+func (d *Deck) dealWeighted(weight func(i int) float64) (Card, error) {
+	if d.IsEmpty() {
+		return Card{}, errors.New("cannot deal from empty deck")
+	}
+
+	weights := make([]float64, d.Size())
+	total := 0.0
+	for i := range weights {
+		weights[i] = weight(i)
+		total += weights[i]
+	}
+
+	pick := len(weights) - 1
+	target := rand.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			pick = i
+			break
+		}
+		target -= w
+	}
+
+	card := d.cards()[pick]
+	d.removeAt(pick)
+	d.dealt(card)
+	return card, nil
+}
+
+// DealBridge deals the standard bridge distribution of 13 cards to each of
+// four players, one card at a time. The deck must be a complete 52-card deck.
+func (d *Deck) DealBridge() ([4][]Card, error) {
+	var hands [4][]Card
+	if !d.isComplete() {
+		return hands, errors.New("bridge requires a complete 52-card deck")
+	}
+
+	dealt, err := d.dealRoundRobin(4, 13)
+	if err != nil {
+		return hands, err
+	}
+	copy(hands[:], dealt)
+	return hands, nil
+}
+
+// DealWithStock deals cardsEach cards to each of the given number of hands,
+// one card at a time, then moves every remaining card into a separate stock
+// deck, leaving this deck empty. It is the usual setup for games such as
+// Rummy that draw from a stock pile after the deal.
+func (d *Deck) DealWithStock(hands, cardsEach int) (dealt [][]Card, stock *Deck, err error) {
+	dealt, err = d.dealRoundRobin(hands, cardsEach)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stock = NewDeckFromCards(d.cards())
+	d.unindex(d.cards()...)
+	d.setCards(d.buf[:0])
+	return dealt, stock, nil
+}
+
+// DealUniqueHands deals hands of the requested sizes from the top of the deck
+// such that no card appears more than once across all hands. Hands are filled
+// in order; cards that duplicate one already dealt are skipped and stay in the
+// deck. An error is returned, and the deck left untouched, if there are not
+// enough distinct cards to fill every hand.
+func (d *Deck) DealUniqueHands(sizes []int) ([][]Card, error) {
+	total := 0
+	for _, size := range sizes {
+		if size < 0 {
+			return nil, errors.New("cannot deal negative number of cards")
+		}
+		total += size
+	}
+
+	dealt := make(map[Card]bool, total)
+	picked := make([]bool, d.Size())
+	cards := make([]Card, 0, total)
+	for i, card := range d.cards() {
+		if len(cards) == total {
+			break
+		}
+		if dealt[card] {
+			continue
+		}
+		dealt[card] = true
+		picked[i] = true
+		cards = append(cards, card)
+	}
+	if len(cards) < total {
+		return nil, errors.New("not enough unique cards in deck")
+	}
+
+	remaining := make([]Card, 0, d.Size()-total)
+	for i, card := range d.cards() {
+		if !picked[i] {
+			remaining = append(remaining, card)
+		}
+	}
+	d.setCards(remaining)
+	d.dealt(cards...)
+
+	hands := make([][]Card, len(sizes))
+	for i, size := range sizes {
+		hands[i] = cards[:size:size]
+		cards = cards[size:]
+	}
+	return hands, nil
+}
+
+// DealAlternating deals the entire deck into the given number of piles, one
+// card at a time. When the cards don't divide evenly the earlier piles get one
+// extra card. It returns nil if piles is not positive.
+func (d *Deck) DealAlternating(piles int) [][]Card {
+	if piles <= 0 {
+		return nil
+	}
+
+	result := make([][]Card, piles)
+	for i := range result {
+		result[i] = make([]Card, 0, (d.Size()+piles-1)/piles)
+	}
+	for i, card := range d.cards() {
+		result[i%piles] = append(result[i%piles], card)
+	}
+	d.dealt(d.cards()...)
+	d.setCards(d.buf[:0])
+	return result
+}
+
+// DealDirectional deals cardsEach cards to each player, one card at a time,
+// as at a real table. Players are numbered clockwise from 0, and the deal
+// begins with player start, usually the one after the dealer, then continues
+// clockwise or counterclockwise around the table. The hands are indexed by
+// player number.
+func (d *Deck) DealDirectional(players, cardsEach int, clockwise bool, start int) ([][]Card, error) {
+	if players > 0 && (start < 0 || start >= players) {
+		return nil, errors.New("start player out of range")
+	}
+
+	dealt, err := d.dealRoundRobin(players, cardsEach)
+	if err != nil {
+		return nil, err
+	}
+
+	hands := make([][]Card, players)
+	for turn, hand := range dealt {
+		player := start + turn
+		if !clockwise {
+			player = start - turn + players
+		}
+		hands[player%players] = hand
+	}
+	return hands, nil
+}
+
+// DealTournament resets the deck to a fresh 52-card deck, shuffles it with
+// ShuffleWithSeed(handSeed), then deals cardsEach cards to each player one
+// card at a time. Every table dealing the same hand seed gets the same cards,
+// as duplicate-format tournaments require. Anything previously in the deck,
+// along with its dealt and seed history, is discarded even if the deal fails.
+func (d *Deck) DealTournament(players, cardsEach int, handSeed int64) ([][]Card, error) {
+	d.Reset()
+	d.ShuffleWithSeed(handSeed)
+	return d.dealRoundRobin(players, cardsEach)
+}
+
+// dealRoundRobin deals cardsEach cards to each player, one card at a time
+func (d *Deck) dealRoundRobin(players, cardsEach int) ([][]Card, error) {
+	if players <= 0 {
+		return nil, errors.New("number of players must be positive")
+	}
+	if cardsEach < 0 {
+		return nil, errors.New("cannot deal negative number of cards")
+	}
+	if players*cardsEach > d.Size() {
+		return nil, errors.New("not enough cards in deck")
+	}
+
+	cards := d.cards()[:players*cardsEach]
+	hands := make([][]Card, players)
+	for i := range hands {
+		hands[i] = make([]Card, 0, cardsEach)
+	}
+	for i, card := range cards {
+		hands[i%players] = append(hands[i%players], card)
+	}
+	d.dealt(cards...)
+	d.top += players * cardsEach
+	return hands, nil
+}
+
+// isComplete reports whether the deck holds exactly one of each standard card
+func (d *Deck) isComplete() bool {
+	if d.Size() != 52 {
+		return false
+	}
+
+	var seen [52]bool
+	for _, card := range d.cards() {
+		if !card.IsValid() {
+			return false
+		}
+		if seen[card.Key()] {
+			return false
+		}
+		seen[card.Key()] = true
+	}
+	return true
+}
+
+// FlattenHands returns the cards from a set of hands as a single slice, in hand order
+func FlattenHands(hands [][]Card) []Card {
+	total := 0
+	for _, hand := range hands {
+		total += len(hand)
+	}
+
+	cards := make([]Card, 0, total)
+	for _, hand := range hands {
+		cards = append(cards, hand...)
+	}
+	return cards
+}
+
 // AddCard adds a card to the bottom of the deck
 func (d *Deck) AddCard(card Card) {
-	d.cards = append(d.cards, card)
+	d.reserve(1)
+	d.buf = append(d.buf, card)
+	d.addIndex(card)
 }
 
-// AddCards adds multiple cards to the bottom of the deck
+// AddCardChecked adds a card to the bottom of the deck, returning an error
+// instead if the card is not valid
+func (d *Deck) AddCardChecked(card Card) error {
+	if !card.IsValid() {
+		return errors.New("invalid card")
+	}
+	d.AddCard(card)
+	return nil
+}
+
+// AddCards adds multiple cards to the bottom of the deck. Storage grows at
+// most once per call, and geometrically like append, so building a shoe one
+// deck at a time stays linear.
 func (d *Deck) AddCards(cards []Card) {
-	d.cards = append(d.cards, cards...)
+	d.reserve(len(cards))
+	d.buf = append(d.buf, cards...)
+	d.addIndex(cards...)
+}
+
+// Undeal returns cards to the deck in their given order, either on top (so
+// cards[0] becomes the top card) or at the bottom. It does not check that the
+// cards were dealt from this deck.
+func (d *Deck) Undeal(cards []Card, toTop bool) {
+	if !toTop {
+		d.AddCards(cards)
+		return
+	}
+
+	if d.top >= len(cards) {
+		// Reuse the space left by dealt cards
+		d.top -= len(cards)
+		copy(d.buf[d.top:], cards)
+	} else {
+		restored := make([]Card, 0, len(cards)+d.Size())
+		restored = append(restored, cards...)
+		d.setCards(append(restored, d.cards()...))
+	}
+	d.addIndex(cards...)
 }
 
 // InsertCard inserts a card at the specified position (0 = top)
 func (d *Deck) InsertCard(card Card, position int) error {
-	if position < 0 || position > len(d.cards) {
+	if position < 0 || position > d.Size() {
 		return errors.New("invalid position")
 	}
 
-	d.cards = append(d.cards, Card{})
-	copy(d.cards[position+1:], d.cards[position:])
-	d.cards[position] = card
+	d.reserve(1)
+	d.buf = append(d.buf, Card{})
+	cards := d.cards()
+	copy(cards[position+1:], cards[position:])
+	cards[position] = card
+	d.addIndex(card)
 	return nil
 }
 
+// InsertRandom inserts a card at a uniformly random position in the deck
+func (d *Deck) InsertRandom(card Card) {
+	// Position can never be out of range, so the error is always nil
+	_ = d.InsertCard(card, rand.Intn(d.Size()+1))
+}
+
 // RemoveCard removes the first occurrence of the specified card
 func (d *Deck) RemoveCard(card Card) bool {
-	for i, c := range d.cards {
+	for i, c := range d.cards() {
 		if c.Suit == card.Suit && c.Rank == card.Rank {
-			d.cards = append(d.cards[:i], d.cards[i+1:]...)
+			d.removeAt(i)
+			d.unindex(c)
 			return true
 		}
 	}
@@ -137,7 +1029,17 @@ func (d *Deck) Peek() (Card, error) {
 	if d.IsEmpty() {
 		return Card{}, errors.New("cannot peek at empty deck")
 	}
-	return d.cards[0], nil
+	return d.cards()[0], nil
+}
+
+// TopMatches reports whether the top card satisfies the predicate, without
+// dealing it
+func (d *Deck) TopMatches(predicate func(Card) bool) (bool, error) {
+	card, err := d.Peek()
+	if err != nil {
+		return false, err
+	}
+	return predicate(card), nil
 }
 
 // PeekN returns the top n cards without removing them from the deck
@@ -145,29 +1047,172 @@ func (d *Deck) PeekN(n int) ([]Card, error) {
 	if n < 0 {
 		return nil, errors.New("cannot peek at negative number of cards")
 	}
-	if n > len(d.cards) {
+	if n > d.Size() {
 		return nil, errors.New("not enough cards in deck")
 	}
 
 	cards := make([]Card, n)
-	copy(cards, d.cards[:n])
+	copy(cards, d.cards()[:n])
 	return cards, nil
 }
 
+// PeekCyclic returns n cards starting at position start, wrapping around the
+// end of the deck as if it were a ring. Any start, including a negative one,
+// is taken modulo the deck size. It returns an empty slice if the deck is
+// empty or n is not positive.
+func (d *Deck) PeekCyclic(start, n int) []Card {
+	if d.Size() == 0 || n <= 0 {
+		return []Card{}
+	}
+
+	live := d.cards()
+	size := len(live)
+	start = ((start % size) + size) % size
+	cards := make([]Card, n)
+	for i := range cards {
+		cards[i] = live[(start+i)%size]
+	}
+	return cards
+}
+
 // Reset resets the deck to a full 52-card deck
 func (d *Deck) Reset() {
-	newDeck := NewDeck()
-	d.cards = newDeck.cards
+	d.setCards(appendStandard(d.buf[:0]))
+	d.history = nil
+	d.seeds = nil
+	d.setAside = nil
+	d.dead = nil
+	d.reshuffles = 0
+	d.reindex()
 }
 
 // Clear removes all cards from the deck
 func (d *Deck) Clear() {
-	d.cards = d.cards[:0]
+	d.setCards(d.buf[:0])
+	d.history = nil
+	d.seeds = nil
+	d.setAside = nil
+	d.dead = nil
+	d.reshuffles = 0
+	d.reindex()
+}
+
+// EnableDealtHistory starts recording every card dealt from the deck.
+// History is off by default so decks that don't need it pay no overhead.
+func (d *Deck) EnableDealtHistory() {
+	d.trackHistory = true
+}
+
+// DealtHistory returns, in order, every card dealt since history was enabled
+// or since the last Reset or Clear
+func (d *Deck) DealtHistory() []Card {
+	history := make([]Card, len(d.history))
+	copy(history, d.history)
+	return history
+}
+
+// EnableSeedHistory starts recording the seed of every ShuffleWithSeed call,
+// so the chain of shuffles that produced the deck's order can be replayed.
+// Shuffle draws from the package source without a seed and is not recorded.
+func (d *Deck) EnableSeedHistory() {
+	d.trackSeeds = true
+}
+
+// SeedHistory returns, in order, the seeds passed to ShuffleWithSeed since
+// seed history was enabled or since the last Reset or Clear
+func (d *Deck) SeedHistory() []int64 {
+	seeds := make([]int64, len(d.seeds))
+	copy(seeds, d.seeds)
+	return seeds
+}
+
+// SetDead marks cards as dead: known to be out of play, such as a visible
+// board or cards shown by a folded player, even though they are still in the
+// deck. Dead cards stay in the deck and can still be dealt, but
+// HandProbability, CountOuts and EquityContext leave them out of the cards
+// they draw from. Each call replaces the previous dead set; Reset and Clear
+// empty it.
+func (d *Deck) SetDead(cards []Card) {
+	d.dead = append([]Card(nil), cards...)
+}
+
+// Dead returns the cards marked dead by SetDead
+func (d *Deck) Dead() []Card {
+	dead := make([]Card, len(d.dead))
+	copy(dead, d.dead)
+	return dead
+}
+
+// live returns a copy of the cards in the deck that are not dead, in deck
+// order. A card marked dead n times removes at most n copies.
+func (d *Deck) live() []Card {
+	if len(d.dead) == 0 {
+		return d.Cards()
+	}
+
+	dead := cardCounts(d.dead)
+	cards := make([]Card, 0, d.Size())
+	for _, card := range d.cards() {
+		if dead[card] > 0 {
+			dead[card]--
+			continue
+		}
+		cards = append(cards, card)
+	}
+	return cards
+}
+
+// dealt does the bookkeeping for cards that have been dealt out of the deck,
+// updating the presence index and dealt history if they are enabled
+func (d *Deck) dealt(cards ...Card) {
+	d.unindex(cards...)
+	if d.trackHistory {
+		d.history = append(d.history, cards...)
+	}
+}
+
+// EnableIndex maintains a count of each card in the deck so that Contains and
+// Count run in constant time. The index is off by default because keeping it
+// up to date adds a little overhead to every mutation.
+func (d *Deck) EnableIndex() {
+	d.index = cardCounts(d.cards())
+}
+
+// reindex rebuilds the presence index, if enabled, from the cards in the deck
+func (d *Deck) reindex() {
+	if d.index != nil {
+		d.index = cardCounts(d.cards())
+	}
+}
+
+// addIndex records cards added to the deck in the presence index, if enabled
+func (d *Deck) addIndex(cards ...Card) {
+	if d.index == nil {
+		return
+	}
+	for _, card := range cards {
+		d.index[card]++
+	}
+}
+
+// unindex records cards removed from the deck in the presence index, if enabled
+func (d *Deck) unindex(cards ...Card) {
+	if d.index == nil {
+		return
+	}
+	for _, card := range cards {
+		if d.index[card]--; d.index[card] <= 0 {
+			delete(d.index, card)
+		}
+	}
 }
 
 // Contains checks if the deck contains a specific card
 func (d *Deck) Contains(card Card) bool {
-	for _, c := range d.cards {
+	if d.index != nil {
+		return d.index[card] > 0
+	}
+	for _, c := range d.cards() {
 		if c.Suit == card.Suit && c.Rank == card.Rank {
 			return true
 		}
@@ -175,10 +1220,115 @@ func (d *Deck) Contains(card Card) bool {
 	return false
 }
 
+// Count returns the number of times a card appears in the deck
+func (d *Deck) Count(card Card) int {
+	if d.index != nil {
+		return d.index[card]
+	}
+
+	count := 0
+	for _, c := range d.cards() {
+		if c == card {
+			count++
+		}
+	}
+	return count
+}
+
+// Diff compares the deck against another deck as multisets of cards. missing
+// holds the cards this deck has that other lacks, and extra holds the cards
+// other has beyond this deck. Both are returned in deck order.
+func (d *Deck) Diff(other *Deck) (missing, extra []Card) {
+	otherCounts := cardCounts(other.cards())
+	for _, card := range d.cards() {
+		if otherCounts[card] > 0 {
+			otherCounts[card]--
+		} else {
+			missing = append(missing, card)
+		}
+	}
+
+	counts := cardCounts(d.cards())
+	for _, card := range other.cards() {
+		if counts[card] > 0 {
+			counts[card]--
+		} else {
+			extra = append(extra, card)
+		}
+	}
+	return missing, extra
+}
+
+// EqualExcept reports whether the two decks hold the same cards, as
+// multisets, once every copy of the cards in ignore is left out of both.
+// Order is not compared.
+func (d *Deck) EqualExcept(other *Deck, ignore []Card) bool {
+	ignored := cardCounts(ignore)
+	counts := cardCounts(d.cards())
+	for _, card := range other.cards() {
+		counts[card]--
+	}
+
+	for card, count := range counts {
+		if count != 0 && ignored[card] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns a new deck of the cards common to both decks, treating
+// them as multisets: a card appearing a times in d and b times in other
+// appears min(a, b) times. Cards are in this deck's order.
+func (d *Deck) Intersect(other *Deck) *Deck {
+	otherCounts := cardCounts(other.cards())
+	var cards []Card
+	for _, card := range d.cards() {
+		if otherCounts[card] > 0 {
+			otherCounts[card]--
+			cards = append(cards, card)
+		}
+	}
+	return NewDeckFromCards(cards)
+}
+
+// Union returns a new deck of the cards in either deck, treating them as
+// multisets: a card appearing a times in d and b times in other appears
+// max(a, b) times. This deck's cards come first, followed by other's extras.
+func (d *Deck) Union(other *Deck) *Deck {
+	cards := d.Cards()
+	counts := cardCounts(d.cards())
+	for _, card := range other.cards() {
+		if counts[card] > 0 {
+			counts[card]--
+		} else {
+			cards = append(cards, card)
+		}
+	}
+	return NewDeckFromCards(cards)
+}
+
+// Subtract returns a new deck of this deck's cards with other's removed,
+// treating them as multisets: a card appearing a times in d and b times in
+// other appears max(a-b, 0) times. Cards are in this deck's order.
+func (d *Deck) Subtract(other *Deck) *Deck {
+	missing, _ := d.Diff(other)
+	return NewDeckFromCards(missing)
+}
+
+// cardCounts returns the number of times each card appears in cards
+func cardCounts(cards []Card) map[Card]int {
+	counts := make(map[Card]int, len(cards))
+	for _, card := range cards {
+		counts[card]++
+	}
+	return counts
+}
+
 // CountBySuit returns the number of cards of each suit in the deck
 func (d *Deck) CountBySuit() map[Suit]int {
 	counts := make(map[Suit]int)
-	for _, card := range d.cards {
+	for _, card := range d.cards() {
 		counts[card.Suit]++
 	}
 	return counts
@@ -187,16 +1337,82 @@ func (d *Deck) CountBySuit() map[Suit]int {
 // CountByRank returns the number of cards of each rank in the deck
 func (d *Deck) CountByRank() map[Rank]int {
 	counts := make(map[Rank]int)
-	for _, card := range d.cards {
+	for _, card := range d.cards() {
 		counts[card.Rank]++
 	}
 	return counts
 }
 
+// CountByColor returns the number of red and black cards in the deck. Colors
+// with no cards are omitted, and invalid cards are counted under the zero
+// Color.
+func (d *Deck) CountByColor() map[Color]int {
+	counts := make(map[Color]int)
+	for _, card := range d.cards() {
+		counts[card.Color()]++
+	}
+	return counts
+}
+
+// GroupByColor returns the cards in the deck grouped by color, each group in
+// deck order
+func (d *Deck) GroupByColor() map[Color][]Card {
+	groups := make(map[Color][]Card)
+	for _, card := range d.cards() {
+		groups[card.Color()] = append(groups[card.Color()], card)
+	}
+	return groups
+}
+
+// GroupByRank returns the cards in the deck grouped by rank, each group in deck order
+func (d *Deck) GroupByRank() map[Rank][]Card {
+	return groupByRank(d.cards())
+}
+
+// Sets returns the ranks that appear two or more times in the deck, mapped to
+// their cards in deck order. The size of each set (pair, three-of-a-kind,
+// four-of-a-kind, ...) is the length of its slice. Map iteration order is
+// unspecified; SetRanks gives the ranks ordered by set size.
+func (d *Deck) Sets() map[Rank][]Card {
+	sets := groupByRank(d.cards())
+	for rank, cards := range sets {
+		if len(cards) < 2 {
+			delete(sets, rank)
+		}
+	}
+	return sets
+}
+
+// SetRanks returns the ranks of the sets reported by Sets, largest set first.
+// Sets of the same size are ordered by rank, Ace low.
+func (d *Deck) SetRanks() []Rank {
+	sets := d.Sets()
+	ranks := make([]Rank, 0, len(sets))
+	for rank := range sets {
+		ranks = append(ranks, rank)
+	}
+	sort.Slice(ranks, func(i, j int) bool {
+		if len(sets[ranks[i]]) != len(sets[ranks[j]]) {
+			return len(sets[ranks[i]]) > len(sets[ranks[j]])
+		}
+		return ranks[i] < ranks[j]
+	})
+	return ranks
+}
+
+// groupByRank groups cards by rank, preserving their order within each group
+func groupByRank(cards []Card) map[Rank][]Card {
+	groups := make(map[Rank][]Card)
+	for _, card := range cards {
+		groups[card.Rank] = append(groups[card.Rank], card)
+	}
+	return groups
+}
+
 // Filter returns a new deck containing only cards that match the predicate
 func (d *Deck) Filter(predicate func(Card) bool) *Deck {
 	var filtered []Card
-	for _, card := range d.cards {
+	for _, card := range d.cards() {
 		if predicate(card) {
 			filtered = append(filtered, card)
 		}
@@ -204,18 +1420,162 @@ func (d *Deck) Filter(predicate func(Card) bool) *Deck {
 	return NewDeckFromCards(filtered)
 }
 
+// Map returns a new deck with fn applied to each card. The original deck is
+// not modified.
+func (d *Deck) Map(fn func(Card) Card) *Deck {
+	mapped := make([]Card, d.Size())
+	for i, card := range d.cards() {
+		mapped[i] = fn(card)
+	}
+	return &Deck{buf: mapped}
+}
+
+// Reduce folds the cards in the deck, from top to bottom, into a single value
+func Reduce[T any](d *Deck, initial T, fn func(acc T, c Card) T) T {
+	acc := initial
+	for _, card := range d.cards() {
+		acc = fn(acc, card)
+	}
+	return acc
+}
+
+// SortMode selects the ordering used by SortBy
+type SortMode int
+
+const (
+	// SuitThenRank orders cards by suit, then by rank within each suit
+	SuitThenRank SortMode = iota
+	// RankThenSuit orders cards by rank, then by suit within each rank
+	RankThenSuit
+)
+
 // Sort sorts the deck by suit first, then by rank
 func (d *Deck) Sort() {
-	// Simple bubble sort for demonstration - could use more efficient algorithm
-	for i := 0; i < len(d.cards)-1; i++ {
-		for j := 0; j < len(d.cards)-i-1; j++ {
-			card1, card2 := d.cards[j], d.cards[j+1]
-
-			// Sort by suit first, then by rank
-			if card1.Suit > card2.Suit ||
-				(card1.Suit == card2.Suit && card1.Rank > card2.Rank) {
-				d.cards[j], d.cards[j+1] = d.cards[j+1], d.cards[j]
-			}
+	d.SortBy(SuitThenRank)
+}
+
+// SortBy sorts the deck using the given sort mode. Sorting is stable, so the
+// result is fully determined by the deck's contents and starting order, even
+// in a shoe holding duplicate cards.
+func (d *Deck) SortBy(mode SortMode) {
+	switch mode {
+	case RankThenSuit:
+		sortStable(d.cards(), func(a, b Card) bool {
+			return a.Rank < b.Rank || (a.Rank == b.Rank && a.Suit < b.Suit)
+		})
+	default:
+		if d.Size() >= radixSortThreshold && d.sortRadix() {
+			return
+		}
+		sortStable(d.cards(), func(a, b Card) bool {
+			return a.SortKey() < b.SortKey()
+		})
+	}
+}
+
+// sortStable sorts cards by less, keeping cards that compare equal in their
+// original order. Every deck sort goes through it, or through sortRadix,
+// rather than the unstable sort.Slice.
+func sortStable(cards []Card, less func(a, b Card) bool) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return less(cards[i], cards[j])
+	})
+}
+
+// SortStable sorts the deck into Sort order, guaranteeing that cards which
+// compare equal keep their current relative order. Sort already makes that
+// guarantee; SortStable states it explicitly for callers that depend on it,
+// and always uses a comparison sort.
+func (d *Deck) SortStable() {
+	sortStable(d.cards(), func(a, b Card) bool {
+		return a.SortKey() < b.SortKey()
+	})
+}
+
+// radixSortThreshold is the deck size from which SortBy(SuitThenRank) uses a
+// counting sort instead of a comparison sort
+const radixSortThreshold = 32
+
+// SortRadix sorts the deck into Sort order using a counting sort over
+// SortKey, which runs in O(n) because there are only 52 distinct keys. It is
+// noticeably faster than a comparison sort for multi-deck shoes. Decks holding
+// invalid cards fall back to a comparison sort.
+func (d *Deck) SortRadix() {
+	if !d.sortRadix() {
+		d.SortBy(SuitThenRank)
+	}
+}
+
+// sortRadix counting-sorts the deck by SortKey, reporting false without
+// changing the deck if it holds a card outside the 52 standard cards. Valid
+// cards with equal keys are identical, so rewriting them in key order is
+// equivalent to a stable sort.
+func (d *Deck) sortRadix() bool {
+	var counts [52]int
+	cards := d.cards()
+	for _, card := range cards {
+		if !card.IsValid() {
+			return false
+		}
+		counts[card.Key()]++
+	}
+
+	i := 0
+	for key, count := range counts {
+		card := CardFromIndex(uint8(key))
+		for ; count > 0; count-- {
+			cards[i] = card
+			i++
 		}
 	}
+	return true
+}
+
+// SortAcesHigh sorts the deck by suit first, then by rank with Aces ranked
+// above Kings
+func (d *Deck) SortAcesHigh() {
+	sortStable(d.cards(), func(a, b Card) bool {
+		return a.Suit < b.Suit || (a.Suit == b.Suit && a.Rank.aceHighValue() < b.Rank.aceHighValue())
+	})
+}
+
+// IsFactoryOrder returns true if the deck holds exactly the 52 cards NewDeck
+// creates, in the same order. A deck still in factory order has never been
+// shuffled, which is worth asserting before a game starts.
+func (d *Deck) IsFactoryOrder() bool {
+	var factory [52]Card
+	appendStandard(factory[:0])
+
+	cards := d.cards()
+	if len(cards) != len(factory) {
+		return false
+	}
+	for i, card := range cards {
+		if card != factory[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSorted returns true if the deck is in Sort order (by suit, then by rank)
+func (d *Deck) IsSorted() bool {
+	cards := d.cards()
+	return sort.SliceIsSorted(cards, func(i, j int) bool {
+		return cards[i].SortKey() < cards[j].SortKey()
+	})
+}
+
+// InsertSorted inserts a card at the position that keeps the deck in Sort
+// order, after any equal cards. The deck must already be sorted (see
+// IsSorted); otherwise the card is inserted at an unspecified position. The
+// position is found by binary search.
+func (d *Deck) InsertSorted(card Card) {
+	key := card.SortKey()
+	cards := d.cards()
+	position := sort.Search(len(cards), func(i int) bool {
+		return cards[i].SortKey() > key
+	})
+	// Position is always in range, so the error is always nil
+	_ = d.InsertCard(card, position)
 }
@@ -1,6 +1,11 @@
 package deck
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
 	"testing"
 )
 
@@ -58,6 +63,26 @@ func TestFaceCard(t *testing.T) {
 	}
 }
 
+func TestFaceCardCustom(t *testing.T) {
+	court := []Rank{Ten, Jack, Queen, King}
+
+	if !NewCard(Clubs, Ten).IsFaceCardCustom(court...) {
+		t.Error("Ten should be a face card when included")
+	}
+
+	if NewCard(Clubs, Ten).IsFaceCard() {
+		t.Error("Ten should not be a face card by default")
+	}
+
+	if NewCard(Hearts, Ace).IsFaceCardCustom(court...) {
+		t.Error("Ace should not be a face card when excluded")
+	}
+
+	if NewCard(Hearts, King).IsFaceCardCustom() {
+		t.Error("No card should be a face card with no ranks given")
+	}
+}
+
 func TestCardColors(t *testing.T) {
 	spadeCard := NewCard(Spades, Ace)
 	heartCard := NewCard(Hearts, Ace)
@@ -81,6 +106,37 @@ func TestCardColors(t *testing.T) {
 	}
 }
 
+func TestCardColor(t *testing.T) {
+	if NewCard(Diamonds, Two).Color() != Red || NewCard(Hearts, Two).Color() != Red {
+		t.Error("Diamonds and Hearts should be red")
+	}
+	if NewCard(Spades, Two).Color() != Black || NewCard(Clubs, Two).Color() != Black {
+		t.Error("Spades and Clubs should be black")
+	}
+	if c := NewCard(Suit(9), Two).Color(); c != 0 || c.String() != "Unknown" {
+		t.Errorf("Expected an invalid suit to have no color, got %v", c)
+	}
+}
+
+func TestCardRelationships(t *testing.T) {
+	aceSpades := NewCard(Spades, Ace)
+	aceHearts := NewCard(Hearts, Ace)
+	twoClubs := NewCard(Clubs, Two)
+
+	if !aceSpades.SameRank(aceHearts) || aceSpades.SameRank(twoClubs) {
+		t.Error("SameRank should compare ranks only")
+	}
+	if !aceSpades.SameSuit(NewCard(Spades, King)) || aceSpades.SameSuit(aceHearts) {
+		t.Error("SameSuit should compare suits only")
+	}
+	if !aceSpades.SameColor(twoClubs) || aceSpades.SameColor(aceHearts) {
+		t.Error("Spades and Clubs should share a color, Spades and Hearts should not")
+	}
+	if invalid := NewCard(Suit(7), Ace); invalid.SameColor(invalid) {
+		t.Error("Cards with invalid suits should not share a color")
+	}
+}
+
 func TestDeal(t *testing.T) {
 	deck := NewDeck()
 	originalSize := deck.Size()
@@ -184,6 +240,142 @@ func TestShuffleWithSeed(t *testing.T) {
 	}
 }
 
+func TestShuffleDoesNotAllocate(t *testing.T) {
+	shoe := NewEmptyDeck()
+	for i := 0; i < 8; i++ {
+		shoe.AddCards(NewDeck().Cards())
+	}
+
+	allocs := testing.AllocsPerRun(100, shoe.Shuffle)
+	if allocs != 0 {
+		t.Errorf("Expected Shuffle not to allocate, got %v allocations", allocs)
+	}
+}
+
+func TestShuffleContext(t *testing.T) {
+	deck := NewDeck()
+	if err := deck.ShuffleContext(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deck.Size() != 52 || deck.IsFactoryOrder() {
+		t.Error("Expected the deck to be shuffled")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	deck = NewDeck()
+	if err := deck.ShuffleContext(ctx); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if !deck.IsFactoryOrder() {
+		t.Error("Expected a shuffle canceled before it started to leave the deck alone")
+	}
+
+	// Canceled after the only check, so the shuffle runs to completion
+	late := &cancelAfterContext{Context: context.Background(), checks: 1}
+	if err := NewDeck().ShuffleContext(late); err != nil {
+		t.Errorf("Expected a completed shuffle to return nil, got %v", err)
+	}
+}
+
+// cancelAfterContext is a context that reports itself canceled once Err has
+// been called checks times
+type cancelAfterContext struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.checks > 0 {
+		c.checks--
+		return nil
+	}
+	return context.Canceled
+}
+
+func TestShuffleWithRand(t *testing.T) {
+	deck1 := NewDeck()
+	deck2 := NewDeck()
+
+	deck1.ShuffleWithSeed(12345)
+	deck2.ShuffleWithRand(rand.New(rand.NewSource(12345)))
+
+	cards1 := deck1.Cards()
+	cards2 := deck2.Cards()
+	for i := range cards1 {
+		if cards1[i] != cards2[i] {
+			t.Fatal("ShuffleWithRand should match ShuffleWithSeed for the same seed")
+		}
+	}
+}
+
+func TestSortedSource(t *testing.T) {
+	deck := NewDeck()
+	deck.Cut(10)
+	expected := deck.Cards()
+
+	deck.ShuffleWithRand(SortedSource{})
+	for i, card := range deck.Cards() {
+		if card != expected[i] {
+			t.Fatalf("Expected SortedSource to leave the order unchanged, got %v at %d", card, i)
+		}
+	}
+}
+
+func TestRecommendedShuffles(t *testing.T) {
+	tests := []struct {
+		size     int
+		expected int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 1},
+		{52, 7},
+		{312, 11},
+	}
+
+	for _, tt := range tests {
+		deck := NewDeckFromCards(make([]Card, tt.size))
+		if got := deck.RecommendedShuffles(); got != tt.expected {
+			t.Errorf("Expected %d shuffles for %d cards, got %d", tt.expected, tt.size, got)
+		}
+	}
+}
+
+func TestCut(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Ace),
+		NewCard(Spades, Two),
+		NewCard(Spades, Three),
+		NewCard(Spades, Four),
+		NewCard(Spades, Five),
+	})
+
+	if err := deck.Cut(2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(deck.Lines()); got != "[3♠ 4♠ 5♠ A♠ 2♠]" {
+		t.Errorf("Expected 3♠ 4♠ 5♠ A♠ 2♠, got %s", got)
+	}
+
+	if err := deck.CutToCard(NewCard(Spades, Ace)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(deck.Lines()); got != "[A♠ 2♠ 3♠ 4♠ 5♠]" {
+		t.Errorf("Expected A♠ 2♠ 3♠ 4♠ 5♠, got %s", got)
+	}
+
+	if err := deck.CutToCard(NewCard(Hearts, Ace)); err == nil {
+		t.Error("Expected error cutting to a missing card")
+	}
+	if err := deck.Cut(6); err == nil {
+		t.Error("Expected error cutting past the bottom of the deck")
+	}
+	if err := deck.Cut(5); err != nil || fmt.Sprint(deck.Lines()) != "[A♠ 2♠ 3♠ 4♠ 5♠]" {
+		t.Errorf("Expected cutting at the bottom to leave the deck unchanged, got %s (%v)", fmt.Sprint(deck.Lines()), err)
+	}
+}
+
 func TestAddCard(t *testing.T) {
 	deck := NewEmptyDeck()
 	card := NewCard(Hearts, Ace)
@@ -251,6 +443,28 @@ func TestPeek(t *testing.T) {
 	}
 }
 
+func TestTopMatches(t *testing.T) {
+	deck := NewDeck()
+
+	matches, err := deck.TopMatches(func(c Card) bool { return c.Rank == Ace })
+	if err != nil || !matches {
+		t.Errorf("Expected top card to be an Ace, got %v (%v)", matches, err)
+	}
+
+	matches, _ = deck.TopMatches(Card.IsRed)
+	if matches {
+		t.Error("Expected top card not to be red")
+	}
+
+	if deck.Size() != 52 {
+		t.Errorf("Expected TopMatches not to deal, got %d cards", deck.Size())
+	}
+
+	if _, err := NewEmptyDeck().TopMatches(Card.IsRed); err == nil {
+		t.Error("Expected error for an empty deck")
+	}
+}
+
 func TestFilter(t *testing.T) {
 	deck := NewDeck()
 
@@ -316,6 +530,30 @@ func TestCountByRank(t *testing.T) {
 	}
 }
 
+func TestCountByColor(t *testing.T) {
+	counts := NewDeck().CountByColor()
+	if counts[Red] != 26 || counts[Black] != 26 {
+		t.Errorf("Expected 26 red and 26 black cards, got %v", counts)
+	}
+
+	// A stripped deck of black cards and one heart
+	stripped := NewDeck().Filter(func(c Card) bool {
+		return c.IsBlack() || c == NewCard(Hearts, Queen)
+	})
+	counts = stripped.CountByColor()
+	if counts[Red] != 1 || counts[Black] != 26 {
+		t.Errorf("Expected 1 red and 26 black cards, got %v", counts)
+	}
+
+	groups := stripped.GroupByColor()
+	if len(groups[Red]) != 1 || groups[Red][0] != NewCard(Hearts, Queen) {
+		t.Errorf("Expected the Queen of Hearts as the only red card, got %v", groups[Red])
+	}
+	if len(groups[Black]) != 26 || groups[Black][0] != NewCard(Spades, Ace) {
+		t.Errorf("Expected 26 black cards in deck order, got %v", groups[Black])
+	}
+}
+
 func TestCardString(t *testing.T) {
 	card := NewCard(Hearts, Ace)
 	expected := "Ace of Hearts"
@@ -337,3 +575,1906 @@ func TestCardShortString(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, card.ShortString())
 	}
 }
+
+func TestFlattenHands(t *testing.T) {
+	hands := [][]Card{
+		{NewCard(Spades, Ace), NewCard(Hearts, Two)},
+		{},
+		{NewCard(Clubs, King)},
+	}
+
+	cards := FlattenHands(hands)
+	expected := []Card{NewCard(Spades, Ace), NewCard(Hearts, Two), NewCard(Clubs, King)}
+	if len(cards) != len(expected) {
+		t.Fatalf("Expected %d cards, got %d", len(expected), len(cards))
+	}
+	for i := range expected {
+		if cards[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, cards[i])
+		}
+	}
+
+	if len(FlattenHands(nil)) != 0 {
+		t.Error("Flattening no hands should return no cards")
+	}
+}
+
+func TestInsertRandom(t *testing.T) {
+	deck := NewEmptyDeck()
+	card := NewCard(Hearts, Queen)
+
+	deck.InsertRandom(card)
+	if deck.Size() != 1 || !deck.Contains(card) {
+		t.Error("Inserting into an empty deck should produce a one-card deck")
+	}
+
+	deck = NewDeck()
+	deck.RemoveCard(card)
+	deck.InsertRandom(card)
+
+	if deck.Size() != 52 {
+		t.Errorf("Expected deck size to be 52, got %d", deck.Size())
+	}
+
+	if !deck.Contains(card) {
+		t.Error("Deck should contain the inserted card")
+	}
+}
+
+func TestDealtHistory(t *testing.T) {
+	deck := NewDeck()
+
+	// History is opt-in
+	deck.Deal()
+	if len(deck.DealtHistory()) != 0 {
+		t.Error("History should be empty when not enabled")
+	}
+
+	deck.EnableDealtHistory()
+	first, _ := deck.Deal()
+	more, _ := deck.DealN(3)
+
+	history := deck.DealtHistory()
+	expected := append([]Card{first}, more...)
+	if len(history) != len(expected) {
+		t.Fatalf("Expected %d cards in history, got %d", len(expected), len(history))
+	}
+	for i := range expected {
+		if history[i] != expected[i] {
+			t.Errorf("Expected %v at history position %d, got %v", expected[i], i, history[i])
+		}
+	}
+
+	deck.Reset()
+	if len(deck.DealtHistory()) != 0 {
+		t.Error("Reset should clear the dealt history")
+	}
+
+	deck.Deal()
+	deck.Clear()
+	if len(deck.DealtHistory()) != 0 {
+		t.Error("Clear should clear the dealt history")
+	}
+}
+
+func TestSeedHistory(t *testing.T) {
+	deck := NewDeck()
+	deck.ShuffleWithSeed(1)
+	if len(deck.SeedHistory()) != 0 {
+		t.Error("Expected no seeds recorded before enabling seed history")
+	}
+
+	deck = NewDeck()
+	deck.EnableSeedHistory()
+	deck.ShuffleWithSeed(7)
+	deck.ShuffleWithAudit(11)
+
+	seeds := deck.SeedHistory()
+	if len(seeds) != 2 || seeds[0] != 7 || seeds[1] != 11 {
+		t.Fatalf("Expected seeds [7 11], got %v", seeds)
+	}
+
+	// Replaying the seeds reproduces the deck
+	replay := NewDeck()
+	for _, seed := range seeds {
+		replay.ShuffleWithSeed(seed)
+	}
+	if replay.Hash() != deck.Hash() {
+		t.Error("Expected replaying the seed history to reproduce the deck")
+	}
+
+	// Unseeded shuffles are not recorded
+	deck.Shuffle()
+	if len(deck.SeedHistory()) != 2 {
+		t.Errorf("Expected Shuffle not to record a seed, got %v", deck.SeedHistory())
+	}
+
+	deck.Reset()
+	if len(deck.SeedHistory()) != 0 {
+		t.Error("Expected Reset to clear the seed history")
+	}
+}
+
+func TestCardKey(t *testing.T) {
+	if key := NewCard(Spades, Ace).Key(); key != 0 {
+		t.Errorf("Expected Ace of Spades to have key 0, got %d", key)
+	}
+
+	if key := NewCard(Clubs, King).Key(); key != 51 {
+		t.Errorf("Expected King of Clubs to have key 51, got %d", key)
+	}
+
+	seen := make(map[uint8]bool)
+	for _, card := range NewDeck().Cards() {
+		key := card.Key()
+		if key > 51 {
+			t.Errorf("Key for %v out of range: %d", card, key)
+		}
+		if seen[key] {
+			t.Errorf("Duplicate key %d for %v", key, card)
+		}
+		seen[key] = true
+
+		if CardFromIndex(key) != card {
+			t.Errorf("Expected CardFromIndex(%d) to return %v, got %v", key, card, CardFromIndex(key))
+		}
+	}
+
+	for _, index := range []uint8{52, 255} {
+		if card := CardFromIndex(index); card.IsValid() {
+			t.Errorf("Expected CardFromIndex(%d) to return an invalid card, got %v", index, card)
+		}
+	}
+}
+
+func TestRandomHand(t *testing.T) {
+	deck := NewDeck()
+
+	hand, err := deck.RandomHand(5)
+	if err != nil {
+		t.Fatalf("Unexpected error dealing random hand: %v", err)
+	}
+
+	if hand.Size() != 5 {
+		t.Errorf("Expected hand size to be 5, got %d", hand.Size())
+	}
+
+	if deck.Size() != 47 {
+		t.Errorf("Expected deck size to be 47 after dealing, got %d", deck.Size())
+	}
+
+	for _, card := range hand.Cards() {
+		if deck.Contains(card) {
+			t.Errorf("Deck should no longer contain %v", card)
+		}
+	}
+
+	_, err = deck.RandomHand(48)
+	if err == nil {
+		t.Error("Expected error when dealing more cards than available")
+	}
+
+	_, err = deck.RandomHand(-1)
+	if err == nil {
+		t.Error("Expected error when dealing negative number of cards")
+	}
+}
+
+func TestDealRandomN(t *testing.T) {
+	deck := NewDeck()
+	hand, err := deck.DealRandomN(5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(hand) != 5 || deck.Size() != 47 {
+		t.Errorf("Expected 5 dealt and 47 remaining, got %d and %d", len(hand), deck.Size())
+	}
+
+	seen := make(map[Card]bool)
+	for _, card := range hand {
+		if seen[card] {
+			t.Errorf("Duplicate card dealt: %v", card)
+		}
+		seen[card] = true
+		if deck.Contains(card) {
+			t.Errorf("Dealt card %v still in deck", card)
+		}
+	}
+
+	if _, err := deck.DealRandomN(48); err == nil {
+		t.Error("Expected error dealing more cards than remain")
+	}
+	if _, err := deck.DealRandomN(-1); err == nil {
+		t.Error("Expected error dealing negative number of cards")
+	}
+
+	// Every card should be reachable, not just those near the top
+	picked := make(map[Card]bool)
+	for i := 0; i < 2000; i++ {
+		cards, _ := NewDeck().DealRandomN(1)
+		picked[cards[0]] = true
+	}
+	if len(picked) != 52 {
+		t.Errorf("Expected all 52 cards to be dealt at some point, got %d", len(picked))
+	}
+}
+
+func TestDealRecencyWeighted(t *testing.T) {
+	const trials = 20000
+	bottom := 0
+	for i := 0; i < trials; i++ {
+		deck := NewDeck()
+		card, err := deck.DealRecencyWeighted(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if deck.Size() != 51 || deck.Contains(card) {
+			t.Fatalf("Expected %v to be removed from the deck", card)
+		}
+		if card == NewCard(Clubs, King) {
+			bottom++
+		}
+	}
+
+	// With a half-life of one the bottom card takes just over half the draws
+	if frac := float64(bottom) / trials; frac < 0.47 || frac > 0.53 {
+		t.Errorf("Expected the bottom card about half the time, got %.3f", frac)
+	}
+
+	if _, err := NewDeck().DealRecencyWeighted(0); err == nil {
+		t.Error("Expected error with a zero half-life")
+	}
+	if _, err := NewEmptyDeck().DealRecencyWeighted(3); err == nil {
+		t.Error("Expected error dealing from an empty deck")
+	}
+}
+
+func TestDealBridge(t *testing.T) {
+	deck := NewDeck()
+	deck.Shuffle()
+
+	hands, err := deck.DealBridge()
+	if err != nil {
+		t.Fatalf("Unexpected error dealing bridge hands: %v", err)
+	}
+
+	seen := make(map[Card]bool)
+	for i, hand := range hands {
+		if len(hand) != 13 {
+			t.Errorf("Expected player %d to have 13 cards, got %d", i, len(hand))
+		}
+		for _, card := range hand {
+			if seen[card] {
+				t.Errorf("Card %v dealt more than once", card)
+			}
+			seen[card] = true
+		}
+	}
+
+	if !deck.IsEmpty() {
+		t.Errorf("Deck should be empty after a bridge deal, got %d cards", deck.Size())
+	}
+
+	// Short deck
+	short := NewDeck()
+	short.Deal()
+	if _, err := short.DealBridge(); err == nil {
+		t.Error("Expected error dealing bridge from a 51-card deck")
+	}
+
+	// Duplicate card
+	dup := NewDeck()
+	dup.Deal()
+	dup.AddCard(NewCard(Clubs, King))
+	if _, err := dup.DealBridge(); err == nil {
+		t.Error("Expected error dealing bridge from a deck with duplicates")
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	deck := NewDeck()
+	deck.Shuffle()
+	deck.SortBy(RankThenSuit)
+
+	cards := deck.Cards()
+	for i := 1; i < len(cards); i++ {
+		prev, curr := cards[i-1], cards[i]
+
+		if prev.Rank > curr.Rank {
+			t.Error("Cards should be sorted by rank")
+		}
+
+		if prev.Rank == curr.Rank && prev.Suit > curr.Suit {
+			t.Error("Cards of same rank should be sorted by suit")
+		}
+	}
+
+	deck.SortBy(SuitThenRank)
+	sorted := NewDeck().Cards()
+	for i, card := range deck.Cards() {
+		if card != sorted[i] {
+			t.Errorf("Expected %v at position %d, got %v", sorted[i], i, card)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	deck := NewDeck()
+	other := NewDeck()
+
+	missing, extra := deck.Diff(other)
+	if len(missing) != 0 || len(extra) != 0 {
+		t.Error("Identical decks should have no differences")
+	}
+
+	lost := NewCard(Hearts, Seven)
+	other.RemoveCard(lost)
+	other.AddCard(NewCard(Spades, Ace)) // duplicate
+
+	missing, extra = deck.Diff(other)
+	if len(missing) != 1 || missing[0] != lost {
+		t.Errorf("Expected missing to be [%v], got %v", lost, missing)
+	}
+	if len(extra) != 1 || extra[0] != NewCard(Spades, Ace) {
+		t.Errorf("Expected extra to be [Ace of Spades], got %v", extra)
+	}
+}
+
+func TestEqualExcept(t *testing.T) {
+	before := NewDeck()
+	after := NewDeck()
+	after.ShuffleWithSeed(3)
+	after.RemoveCard(NewCard(Hearts, Ace))
+	after.RemoveCard(NewCard(Clubs, Two))
+
+	dealt := []Card{NewCard(Hearts, Ace), NewCard(Clubs, Two)}
+	if !before.EqualExcept(after, dealt) {
+		t.Error("Expected decks to match once the dealt cards are ignored")
+	}
+
+	if before.EqualExcept(after, dealt[:1]) {
+		t.Error("Expected decks to differ when a removed card is not ignored")
+	}
+
+	// A duplicate of a card that is not ignored is a difference
+	after.AddCard(NewCard(Spades, King))
+	if before.EqualExcept(after, dealt) {
+		t.Error("Expected an extra King of Spades to be a difference")
+	}
+}
+
+func TestTryDeal(t *testing.T) {
+	deck := NewDeckFromCards([]Card{NewCard(Clubs, Two)})
+
+	card, ok := deck.TryDeal()
+	if !ok {
+		t.Error("Expected to deal a card")
+	}
+	if card != NewCard(Clubs, Two) {
+		t.Errorf("Expected Two of Clubs, got %v", card)
+	}
+
+	card, ok = deck.TryDeal()
+	if ok {
+		t.Error("Expected dealing from empty deck to fail")
+	}
+	if card != (Card{}) {
+		t.Errorf("Expected zero card from empty deck, got %v", card)
+	}
+}
+
+func TestColorString(t *testing.T) {
+	heart := NewCard(Hearts, Ace)
+	spade := NewCard(Spades, King)
+
+	expected := "\x1b[31mA♥\x1b[0m"
+	if heart.ColorString() != expected {
+		t.Errorf("Expected %q, got %q", expected, heart.ColorString())
+	}
+
+	if spade.ColorString() != "K♠" {
+		t.Errorf("Expected %q, got %q", "K♠", spade.ColorString())
+	}
+
+	deck := NewDeckFromCards([]Card{heart, spade})
+	expected = "\x1b[31mA♥\x1b[0m K♠"
+	if deck.ColorString() != expected {
+		t.Errorf("Expected %q, got %q", expected, deck.ColorString())
+	}
+
+	NoColor = true
+	defer func() { NoColor = false }()
+
+	if heart.ColorString() != "A♥" {
+		t.Errorf("Expected %q with color disabled, got %q", "A♥", heart.ColorString())
+	}
+}
+
+func TestDealUniqueHands(t *testing.T) {
+	// Two-deck shoe, unshuffled so duplicates are 52 cards apart
+	shoe := NewDeck()
+	shoe.AddCards(NewDeck().Cards())
+	shoe.InsertCard(NewCard(Spades, Ace), 1)
+
+	hands, err := shoe.DealUniqueHands([]int{2, 3})
+	if err != nil {
+		t.Fatalf("Unexpected error dealing unique hands: %v", err)
+	}
+
+	if len(hands) != 2 || len(hands[0]) != 2 || len(hands[1]) != 3 {
+		t.Fatalf("Unexpected hand sizes: %v", hands)
+	}
+
+	seen := make(map[Card]bool)
+	for _, card := range FlattenHands(hands) {
+		if seen[card] {
+			t.Errorf("Card %v dealt more than once", card)
+		}
+		seen[card] = true
+	}
+
+	// The skipped duplicate stays on top of the deck
+	if top, _ := shoe.Peek(); top != NewCard(Spades, Ace) {
+		t.Errorf("Expected skipped Ace of Spades on top, got %v", top)
+	}
+	if shoe.Size() != 105-5 {
+		t.Errorf("Expected %d cards remaining, got %d", 105-5, shoe.Size())
+	}
+
+	// Not enough distinct cards
+	small := NewDeckFromCards([]Card{NewCard(Hearts, Two), NewCard(Hearts, Two), NewCard(Hearts, Three)})
+	if _, err := small.DealUniqueHands([]int{1, 2}); err == nil {
+		t.Error("Expected error when uniqueness cannot be satisfied")
+	}
+	if small.Size() != 3 {
+		t.Error("Deck should be unchanged after a failed deal")
+	}
+}
+
+func TestMap(t *testing.T) {
+	deck := NewDeck()
+
+	// Turn all face cards into Aces
+	mapped := deck.Map(func(c Card) Card {
+		if c.IsFaceCard() {
+			return NewCard(c.Suit, Ace)
+		}
+		return c
+	})
+
+	if mapped.Size() != 52 {
+		t.Errorf("Expected mapped deck size to be 52, got %d", mapped.Size())
+	}
+
+	if counts := mapped.CountByRank(); counts[Ace] != 16 || counts[King] != 0 {
+		t.Errorf("Expected 16 Aces and no Kings, got %d and %d", counts[Ace], counts[King])
+	}
+
+	if deck.CountByRank()[King] != 4 {
+		t.Error("Map should not modify the original deck")
+	}
+}
+
+func TestReduce(t *testing.T) {
+	deck := NewDeck()
+
+	red := Reduce(deck, 0, func(acc int, c Card) int {
+		if c.IsRed() {
+			return acc + 1
+		}
+		return acc
+	})
+	if red != 26 {
+		t.Errorf("Expected 26 red cards, got %d", red)
+	}
+
+	empty := Reduce(NewEmptyDeck(), "start", func(acc string, c Card) string {
+		return acc + c.ShortString()
+	})
+	if empty != "start" {
+		t.Errorf("Expected initial value for empty deck, got %q", empty)
+	}
+}
+
+func ExampleReduce() {
+	hand := NewDeckFromCards([]Card{
+		NewCard(Hearts, Seven),
+		NewCard(Spades, King),
+		NewCard(Clubs, Ace),
+	})
+
+	// Sum pip values, counting face cards as 10
+	total := Reduce(hand, 0, func(acc int, c Card) int {
+		if c.IsFaceCard() {
+			return acc + 10
+		}
+		return acc + int(c.Rank)
+	})
+	fmt.Println(total)
+	// Output: 18
+}
+
+func TestGroupByRank(t *testing.T) {
+	groups := NewDeck().GroupByRank()
+
+	if len(groups) != 13 {
+		t.Errorf("Expected 13 rank groups, got %d", len(groups))
+	}
+
+	for rank, cards := range groups {
+		if len(cards) != 4 {
+			t.Errorf("Expected 4 cards of rank %v, got %d", rank, len(cards))
+		}
+		for _, card := range cards {
+			if card.Rank != rank {
+				t.Errorf("Card %v grouped under rank %v", card, rank)
+			}
+		}
+	}
+}
+
+func TestSets(t *testing.T) {
+	hand := NewDeckFromCards([]Card{
+		NewCard(Hearts, Nine),
+		NewCard(Spades, Four),
+		NewCard(Clubs, Nine),
+		NewCard(Diamonds, Four),
+		NewCard(Hearts, Four),
+		NewCard(Spades, King),
+	})
+
+	sets := hand.Sets()
+	if len(sets) != 2 {
+		t.Fatalf("Expected 2 sets, got %d", len(sets))
+	}
+
+	if len(sets[Nine]) != 2 {
+		t.Errorf("Expected a pair of Nines, got %v", sets[Nine])
+	}
+
+	if len(sets[Four]) != 3 {
+		t.Errorf("Expected three Fours, got %v", sets[Four])
+	}
+
+	if _, ok := sets[King]; ok {
+		t.Error("A single King should not be a set")
+	}
+
+	hand.AddCards([]Card{NewCard(Clubs, Two), NewCard(Hearts, Two)})
+	ranks := hand.SetRanks()
+	if len(ranks) != 3 || ranks[0] != Four || ranks[1] != Two || ranks[2] != Nine {
+		t.Errorf("Expected Four, Two, Nine, got %v", ranks)
+	}
+	if ranks := NewDeckFromCards(nil).SetRanks(); len(ranks) != 0 {
+		t.Errorf("Expected no set ranks for an empty deck, got %v", ranks)
+	}
+}
+
+func TestLines(t *testing.T) {
+	deck := NewDeckFromCards([]Card{NewCard(Hearts, Ten), NewCard(Spades, Ace)})
+
+	lines := deck.Lines()
+	if len(lines) != 2 || lines[0] != "10♥" || lines[1] != "A♠" {
+		t.Errorf("Expected [10♥ A♠], got %v", lines)
+	}
+
+	lines = NewEmptyDeck().Lines()
+	if lines == nil || len(lines) != 0 {
+		t.Errorf("Expected empty non-nil slice for empty deck, got %#v", lines)
+	}
+}
+
+func TestNewDeckExcluding(t *testing.T) {
+	seen := []Card{NewCard(Hearts, Ace), NewCard(Clubs, Ten), NewCard(Hearts, Ace), {}}
+	deck := NewDeckExcluding(seen...)
+
+	if deck.Size() != 50 {
+		t.Errorf("Expected 50 cards, got %d", deck.Size())
+	}
+	if deck.Contains(NewCard(Hearts, Ace)) || deck.Contains(NewCard(Clubs, Ten)) {
+		t.Error("Excluded cards should not be in the deck")
+	}
+
+	if NewDeckExcluding().Size() != 52 {
+		t.Error("Excluding nothing should give a full deck")
+	}
+}
+
+func TestNewSpanishDeck(t *testing.T) {
+	deck := NewSpanishDeck()
+
+	if deck.Size() != 40 {
+		t.Errorf("Expected deck size to be 40, got %d", deck.Size())
+	}
+
+	counts := deck.CountByRank()
+	for _, rank := range SpanishOmittedRanks() {
+		if counts[rank] != 0 {
+			t.Errorf("Expected no cards of rank %v, got %d", rank, counts[rank])
+		}
+	}
+
+	// Changing the returned ranks must not change the deck's makeup
+	SpanishOmittedRanks()[0] = King
+	if NewSpanishDeck().CountByRank()[King] != 4 {
+		t.Error("Expected SpanishOmittedRanks to return a copy")
+	}
+
+	for _, suit := range []Suit{Coins, Cups, Swords, Batons} {
+		if deck.CountBySuit()[suit] != 10 {
+			t.Errorf("Expected 10 cards of suit %v, got %d", suit, deck.CountBySuit()[suit])
+		}
+	}
+}
+
+func TestDealAlternating(t *testing.T) {
+	deck := NewDeck()
+	top, _ := deck.PeekN(3)
+
+	piles := deck.DealAlternating(5)
+	if len(piles) != 5 {
+		t.Fatalf("Expected 5 piles, got %d", len(piles))
+	}
+
+	// 52 = 5*10 + 2, so the first two piles get an extra card
+	expectedSizes := []int{11, 11, 10, 10, 10}
+	for i, pile := range piles {
+		if len(pile) != expectedSizes[i] {
+			t.Errorf("Expected pile %d to have %d cards, got %d", i, expectedSizes[i], len(pile))
+		}
+	}
+
+	if piles[0][0] != top[0] || piles[1][0] != top[1] || piles[2][0] != top[2] {
+		t.Error("Cards should be dealt one at a time across the piles")
+	}
+
+	if !deck.IsEmpty() {
+		t.Errorf("Deck should be empty after dealing, got %d cards", deck.Size())
+	}
+
+	if NewDeck().DealAlternating(0) != nil {
+		t.Error("Expected nil when dealing to zero piles")
+	}
+}
+
+func TestRankNext(t *testing.T) {
+	if next, ok := Two.Next(false); !ok || next != Three {
+		t.Errorf("Expected Two to be followed by Three, got %v, %v", next, ok)
+	}
+
+	if next, ok := Ace.Next(false); !ok || next != Two {
+		t.Errorf("Expected Ace low to be followed by Two, got %v, %v", next, ok)
+	}
+
+	if _, ok := King.Next(false); ok {
+		t.Error("King should have no next rank with Ace low")
+	}
+
+	if next, ok := King.Next(true); !ok || next != Ace {
+		t.Errorf("Expected King to be followed by Ace when Ace is high, got %v, %v", next, ok)
+	}
+
+	if _, ok := Ace.Next(true); ok {
+		t.Error("Ace high should have no next rank")
+	}
+}
+
+func TestRankArithmetic(t *testing.T) {
+	tests := []struct {
+		got      Rank
+		expected Rank
+	}{
+		{King.Add(1), King},
+		{Ace.Sub(1), Ace},
+		{Ten.Add(2), Queen},
+		{Five.Sub(3), Two},
+		{Three.Add(-5), Ace},
+		{Jack.Sub(-10), King},
+		{King.AddWrap(1), Ace},
+		{Ace.SubWrap(1), King},
+		{Queen.AddWrap(3), Two},
+		{Two.SubWrap(28), King},
+		{Seven.AddWrap(13), Seven},
+		{Rank(0).Add(1), Rank(0)},
+		{Rank(20).AddWrap(1), Rank(20)},
+	}
+
+	for i, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("Case %d: expected %v, got %v", i, tt.expected, tt.got)
+		}
+	}
+}
+
+func TestIsAdjacentTo(t *testing.T) {
+	five := NewCard(Hearts, Five)
+
+	if !five.IsAdjacentTo(NewCard(Spades, Six)) || !five.IsAdjacentTo(NewCard(Clubs, Four)) {
+		t.Error("Five should be adjacent to Four and Six")
+	}
+
+	if five.IsAdjacentTo(NewCard(Hearts, Seven)) || five.IsAdjacentTo(NewCard(Hearts, Five)) {
+		t.Error("Five should not be adjacent to Seven or Five")
+	}
+
+	ace := NewCard(Spades, Ace)
+	if !ace.IsAdjacentTo(NewCard(Hearts, Two)) || !ace.IsAdjacentTo(NewCard(Hearts, King)) {
+		t.Error("Ace should be adjacent to both Two and King")
+	}
+
+	if NewCard(Hearts, King).IsAdjacentTo(NewCard(Hearts, Two)) {
+		t.Error("King should not wrap around to Two")
+	}
+}
+
+func TestDealToWriter(t *testing.T) {
+	deck := NewDeckFromCards([]Card{NewCard(Hearts, Ace), NewCard(Spades, Ten), NewCard(Clubs, Two)})
+	var buf bytes.Buffer
+
+	n, err := deck.DealToWriter(&buf, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error dealing to writer: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 cards written, got %d", n)
+	}
+	if buf.String() != "A♥\n10♠\n" {
+		t.Errorf("Unexpected output %q", buf.String())
+	}
+
+	// Running out stops cleanly
+	buf.Reset()
+	n, err = deck.DealToWriter(&buf, 5)
+	if err != nil {
+		t.Errorf("Unexpected error when deck runs out: %v", err)
+	}
+	if n != 1 || buf.String() != "2♣\n" {
+		t.Errorf("Expected one card written, got %d (%q)", n, buf.String())
+	}
+
+	if !deck.IsEmpty() {
+		t.Error("Deck should be empty after dealing every card")
+	}
+}
+
+func TestCardGlyph(t *testing.T) {
+	if g := NewCard(Spades, Ace).Glyph(); g != '\U0001F0A1' {
+		t.Errorf("Expected Ace of Spades glyph U+1F0A1, got %U", g)
+	}
+
+	card, err := ParseGlyph('\U0001F0AE')
+	if err != nil || card != NewCard(Spades, King) {
+		t.Errorf("Expected U+1F0AE to parse as King of Spades, got %v (%v)", card, err)
+	}
+
+	for _, card := range NewDeck().Cards() {
+		parsed, err := ParseGlyph(card.Glyph())
+		if err != nil {
+			t.Errorf("Unexpected error parsing glyph for %v: %v", card, err)
+		}
+		if parsed != card {
+			t.Errorf("Expected %v to round-trip, got %v", card, parsed)
+		}
+	}
+
+	// Knight of Spades, card back and a rune outside the block
+	for _, r := range []rune{'\U0001F0AC', '\U0001F0A0', 'A'} {
+		if _, err := ParseGlyph(r); err == nil {
+			t.Errorf("Expected error parsing %U", r)
+		}
+	}
+}
+
+func TestCardShortStringCompact(t *testing.T) {
+	card := NewCard(Hearts, Ten)
+	expected := "T♥"
+	if card.ShortStringCompact() != expected {
+		t.Errorf("Expected %s, got %s", expected, card.ShortStringCompact())
+	}
+
+	if card.ShortString() != "10♥" {
+		t.Errorf("ShortString should still render Ten as 10, got %s", card.ShortString())
+	}
+
+	for _, c := range NewDeck().Cards() {
+		if n := len([]rune(c.ShortStringCompact())); n != 2 {
+			t.Errorf("Expected %v to render as 2 runes, got %d", c, n)
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	ace := NewCard(Spades, Ace)
+	king := NewCard(Hearts, King)
+	two := NewCard(Clubs, Two)
+
+	// Shoe-like decks with duplicates
+	a := NewDeckFromCards([]Card{ace, ace, ace, king})
+	b := NewDeckFromCards([]Card{ace, king, king, two})
+
+	intersect := a.Intersect(b).CountByRank()
+	if intersect[Ace] != 1 || intersect[King] != 1 || intersect[Two] != 0 {
+		t.Errorf("Unexpected intersection counts: %v", intersect)
+	}
+
+	union := a.Union(b)
+	if union.Size() != 6 {
+		t.Errorf("Expected union size to be 6, got %d", union.Size())
+	}
+	unionCounts := union.CountByRank()
+	if unionCounts[Ace] != 3 || unionCounts[King] != 2 || unionCounts[Two] != 1 {
+		t.Errorf("Unexpected union counts: %v", unionCounts)
+	}
+
+	subtract := a.Subtract(b).CountByRank()
+	if subtract[Ace] != 2 || subtract[King] != 0 {
+		t.Errorf("Unexpected subtraction counts: %v", subtract)
+	}
+
+	if a.Size() != 4 || b.Size() != 4 {
+		t.Error("Set operations should not modify the original decks")
+	}
+}
+
+func TestPeekCyclic(t *testing.T) {
+	a, b, c := NewCard(Spades, Ace), NewCard(Hearts, Two), NewCard(Clubs, Three)
+	deck := NewDeckFromCards([]Card{a, b, c})
+
+	cards := deck.PeekCyclic(2, 4)
+	expected := []Card{c, a, b, c}
+	if len(cards) != len(expected) {
+		t.Fatalf("Expected %d cards, got %d", len(expected), len(cards))
+	}
+	for i := range expected {
+		if cards[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, cards[i])
+		}
+	}
+
+	if cards := deck.PeekCyclic(-1, 1); cards[0] != c {
+		t.Errorf("Expected negative start to wrap to the bottom card, got %v", cards[0])
+	}
+
+	if deck.Size() != 3 {
+		t.Error("PeekCyclic should not modify the deck")
+	}
+
+	if cards := NewEmptyDeck().PeekCyclic(0, 3); len(cards) != 0 {
+		t.Errorf("Expected no cards from empty deck, got %v", cards)
+	}
+}
+
+func TestCount(t *testing.T) {
+	shoe := NewDeck()
+	shoe.AddCards(NewDeck().Cards())
+
+	if count := shoe.Count(NewCard(Hearts, Ace)); count != 2 {
+		t.Errorf("Expected 2 Aces of Hearts in a two-deck shoe, got %d", count)
+	}
+
+	if count := NewEmptyDeck().Count(NewCard(Hearts, Ace)); count != 0 {
+		t.Errorf("Expected 0 in an empty deck, got %d", count)
+	}
+}
+
+func TestEnableIndex(t *testing.T) {
+	indexed := NewDeck()
+	indexed.EnableIndex()
+	plain := NewDeck()
+
+	check := func(step string) {
+		t.Helper()
+		for _, card := range NewDeck().Cards() {
+			if indexed.Count(card) != plain.Count(card) || indexed.Contains(card) != plain.Contains(card) {
+				t.Fatalf("%s: index out of sync for %v: got %d, want %d", step, card, indexed.Count(card), plain.Count(card))
+			}
+		}
+	}
+
+	check("new")
+
+	for _, d := range []*Deck{indexed, plain} {
+		d.Deal()
+		d.DealN(3)
+		d.AddCard(NewCard(Clubs, King))
+		d.AddCards([]Card{NewCard(Spades, Ace), NewCard(Spades, Ace)})
+		d.InsertCard(NewCard(Hearts, Two), 5)
+		d.RemoveCard(NewCard(Diamonds, Nine))
+		d.DealUniqueHands([]int{2, 2})
+	}
+	check("after mutations")
+
+	indexed.Clear()
+	plain.Clear()
+	check("after clear")
+
+	indexed.Reset()
+	plain.Reset()
+	check("after reset")
+}
+
+func TestSortAcesHigh(t *testing.T) {
+	deck := NewDeck()
+	deck.Shuffle()
+	deck.SortAcesHigh()
+
+	cards := deck.Cards()
+	if cards[0] != NewCard(Spades, Two) {
+		t.Errorf("Expected Two of Spades first, got %v", cards[0])
+	}
+	if cards[12] != NewCard(Spades, Ace) {
+		t.Errorf("Expected Ace of Spades to follow the King, got %v", cards[12])
+	}
+	if cards[51] != NewCard(Clubs, Ace) {
+		t.Errorf("Expected Ace of Clubs last, got %v", cards[51])
+	}
+}
+
+func TestDealWithStock(t *testing.T) {
+	deck := NewDeck()
+
+	hands, stock, err := deck.DealWithStock(2, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error dealing with stock: %v", err)
+	}
+
+	if len(hands) != 2 || len(hands[0]) != 10 || len(hands[1]) != 10 {
+		t.Errorf("Expected two hands of 10 cards, got %v", hands)
+	}
+
+	if stock.Size() != 32 {
+		t.Errorf("Expected stock of 32 cards, got %d", stock.Size())
+	}
+
+	if !deck.IsEmpty() {
+		t.Errorf("Deck should be empty after moving the stock, got %d cards", deck.Size())
+	}
+
+	if _, _, err := NewDeck().DealWithStock(6, 10); err == nil {
+		t.Error("Expected error when dealing more cards than available")
+	}
+}
+
+func TestCardIsValid(t *testing.T) {
+	for _, card := range NewDeck().Cards() {
+		if !card.IsValid() {
+			t.Errorf("Expected %v to be valid", card)
+		}
+	}
+
+	invalid := []Card{{}, NewCard(Clubs+1, Ace), NewCard(Spades, King+1), NewCard(-1, Two)}
+	for _, card := range invalid {
+		if card.IsValid() {
+			t.Errorf("Expected %#v to be invalid", card)
+		}
+	}
+}
+
+func TestAddCardChecked(t *testing.T) {
+	deck := NewEmptyDeck()
+
+	if err := deck.AddCardChecked(NewCard(Hearts, Ace)); err != nil {
+		t.Errorf("Unexpected error adding valid card: %v", err)
+	}
+
+	if err := deck.AddCardChecked(Card{}); err == nil {
+		t.Error("Expected error adding zero-value card")
+	}
+
+	if deck.Size() != 1 {
+		t.Errorf("Expected deck size to be 1, got %d", deck.Size())
+	}
+}
+
+// positionChiSquared shuffles a 10-card deck repeatedly and returns the
+// chi-squared statistic for the landing position of the top card
+func positionChiSquared(shuffle func(d *Deck)) float64 {
+	const size, trials = 10, 20000
+	marked := NewCard(Spades, Ace)
+
+	var counts [size]int
+	for i := 0; i < trials; i++ {
+		deck := NewEmptyDeck()
+		for rank := Ace; rank <= Ten; rank++ {
+			deck.AddCard(NewCard(Spades, rank))
+		}
+		shuffle(deck)
+		for pos, card := range deck.Cards() {
+			if card == marked {
+				counts[pos]++
+			}
+		}
+	}
+
+	expected := float64(trials) / size
+	chi := 0.0
+	for _, observed := range counts {
+		diff := float64(observed) - expected
+		chi += diff * diff / expected
+	}
+	return chi
+}
+
+func TestShuffleUnbiased(t *testing.T) {
+	deck := NewDeck()
+	deck.ShuffleUnbiased()
+
+	if deck.Size() != 52 {
+		t.Errorf("Shuffled deck should still have 52 cards, got %d", deck.Size())
+	}
+	for _, card := range NewDeck().Cards() {
+		if !deck.Contains(card) {
+			t.Errorf("Shuffled deck is missing %v", card)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, deck.ShuffleUnbiased); allocs != 0 {
+		t.Errorf("Expected ShuffleUnbiased not to allocate, got %v allocations", allocs)
+	}
+
+	// Critical value for 9 degrees of freedom at p = 0.001
+	const critical = 27.877
+	r := rand.New(rand.NewSource(1))
+
+	if chi := positionChiSquared(func(d *Deck) { d.shuffleUnbiased(r) }); chi > critical {
+		t.Errorf("ShuffleUnbiased positions not uniform: chi-squared %.2f exceeds %.2f", chi, critical)
+	}
+
+	seed := int64(0)
+	if chi := positionChiSquared(func(d *Deck) { seed++; d.ShuffleWithSeed(seed) }); chi > critical {
+		t.Errorf("ShuffleWithSeed positions not uniform: chi-squared %.2f exceeds %.2f", chi, critical)
+	}
+}
+
+func TestUnbiasedIntn(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []uint64{1, 2, 3, 7, 52, 416, 1 << 63} {
+		for i := 0; i < 100; i++ {
+			if v := unbiasedIntn(r, n); v >= n {
+				t.Fatalf("unbiasedIntn(%d) returned out-of-range %d", n, v)
+			}
+		}
+	}
+}
+
+func TestDealInto(t *testing.T) {
+	deck := NewDeck()
+	buf := make([]Card, 5)
+
+	n, err := deck.DealInto(buf)
+	if err != nil || n != 5 {
+		t.Fatalf("Expected to deal 5 cards, got %d (%v)", n, err)
+	}
+	for i, rank := range []Rank{Ace, Two, Three, Four, Five} {
+		if buf[i] != NewCard(Spades, rank) {
+			t.Errorf("Expected %v at position %d, got %v", NewCard(Spades, rank), i, buf[i])
+		}
+	}
+
+	allocs := testing.AllocsPerRun(5, func() {
+		deck.DealInto(buf)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected DealInto not to allocate, got %v allocations", allocs)
+	}
+
+	// AllocsPerRun dealt six more hands of 5, leaving 17; a larger buffer
+	// takes them all without error
+	big := make([]Card, 20)
+	n, err = deck.DealInto(big)
+	if err != nil || n != 17 {
+		t.Errorf("Expected to deal the remaining 17 cards, got %d (%v)", n, err)
+	}
+
+	if n, err := deck.DealInto(buf); err != nil || n != 0 {
+		t.Errorf("Expected an empty deck to deal 0 cards, got %d (%v)", n, err)
+	}
+	if n, err := deck.DealInto(nil); err != nil || n != 0 {
+		t.Errorf("Expected dealing into an empty buffer to do nothing, got %d (%v)", n, err)
+	}
+}
+
+func TestDealBest(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Five),
+		NewCard(Hearts, Nine),
+		NewCard(Clubs, Nine),
+		NewCard(Diamonds, King),
+	})
+	higher := func(a, b Card) bool { return a.Rank > b.Rank }
+
+	card, err := deck.DealBest(3, higher)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// King is out of view; the tie between Nines goes to the earlier card
+	if card != NewCard(Hearts, Nine) {
+		t.Errorf("Expected Nine of Hearts, got %v", card)
+	}
+
+	cards := deck.Cards()
+	expected := []Card{NewCard(Spades, Five), NewCard(Clubs, Nine), NewCard(Diamonds, King)}
+	for i := range expected {
+		if cards[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, cards[i])
+		}
+	}
+
+	if _, err := deck.DealBest(4, higher); err == nil {
+		t.Error("Expected error looking past the bottom of the deck")
+	}
+	if _, err := deck.DealBest(0, higher); err == nil {
+		t.Error("Expected error looking at no cards")
+	}
+}
+
+func TestTopK(t *testing.T) {
+	deck := NewDeck()
+	deck.ShuffleWithSeed(8)
+	before := deck.Cards()
+	higher := func(a, b Card) bool { return a.Rank.aceHighValue() > b.Rank.aceHighValue() }
+
+	top, err := deck.TopK(5, higher)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Four Aces, then the first King in deck order
+	var firstKing Card
+	for _, card := range before {
+		if card.Rank == King {
+			firstKing = card
+			break
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if top[i].Rank != Ace {
+			t.Errorf("Expected an Ace at position %d, got %v", i, top[i])
+		}
+	}
+	if top[4] != firstKing {
+		t.Errorf("Expected %v, the first King in the deck, got %v", firstKing, top[4])
+	}
+
+	// Tied Aces come out in deck order
+	var aces []Card
+	for _, card := range before {
+		if card.Rank == Ace {
+			aces = append(aces, card)
+		}
+	}
+	for i := range aces {
+		if top[i] != aces[i] {
+			t.Errorf("Expected %v at position %d, got %v", aces[i], i, top[i])
+		}
+	}
+
+	after := deck.Cards()
+	for i := range before {
+		if before[i] != after[i] {
+			t.Fatal("TopK should not modify the deck")
+		}
+	}
+
+	if top, err := deck.TopK(0, higher); err != nil || len(top) != 0 {
+		t.Errorf("Expected no cards for k of 0, got %v (%v)", top, err)
+	}
+	if _, err := deck.TopK(53, higher); err == nil {
+		t.Error("Expected error for k larger than the deck")
+	}
+	if _, err := deck.TopK(-1, higher); err == nil {
+		t.Error("Expected error for negative k")
+	}
+}
+
+func TestDealMatching(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Two),
+		NewCard(Hearts, Three),
+		NewCard(Clubs, Four),
+		NewCard(Diamonds, Five),
+		NewCard(Spades, Six),
+		NewCard(Hearts, Seven),
+	})
+	isRed := func(c Card) bool { return c.IsRed() }
+
+	cards, err := deck.DealMatching(2, isRed)
+	if err != nil {
+		t.Fatalf("Unexpected error dealing matching cards: %v", err)
+	}
+	if len(cards) != 2 || cards[0] != NewCard(Hearts, Three) || cards[1] != NewCard(Diamonds, Five) {
+		t.Errorf("Expected Three of Hearts and Five of Diamonds, got %v", cards)
+	}
+
+	aside := deck.SetAside()
+	if len(aside) != 2 || aside[0] != NewCard(Spades, Two) || aside[1] != NewCard(Clubs, Four) {
+		t.Errorf("Expected skipped black cards set aside, got %v", aside)
+	}
+
+	if deck.Size() != 2 {
+		t.Errorf("Expected 2 cards remaining, got %d", deck.Size())
+	}
+
+	// Only one red card left
+	if _, err := deck.DealMatching(2, isRed); err == nil {
+		t.Error("Expected error when not enough matching cards")
+	}
+	if deck.Size() != 2 {
+		t.Error("Deck should be unchanged after a failed deal")
+	}
+
+	deck.Reset()
+	if len(deck.SetAside()) != 0 {
+		t.Error("Reset should clear the set-aside pile")
+	}
+}
+
+func TestDealMatchingStatefulPredicate(t *testing.T) {
+	// Matches the first card of each suit not seen before, so asking it twice
+	// about the same card gives different answers
+	seen := make(map[Suit]bool)
+	newSuit := func(c Card) bool {
+		if seen[c.Suit] {
+			return false
+		}
+		seen[c.Suit] = true
+		return true
+	}
+
+	deck := NewDeck()
+	cards, err := deck.DealMatching(2, newSuit)
+	if err != nil {
+		t.Fatalf("Unexpected error dealing matching cards: %v", err)
+	}
+	if len(cards) != 2 || cards[0] != NewCard(Spades, Ace) || cards[1] != NewCard(Hearts, Ace) {
+		t.Errorf("Expected the Aces of Spades and Hearts, got %v", cards)
+	}
+
+	aside := deck.SetAside()
+	for _, card := range aside {
+		if card.Rank == Ace {
+			t.Errorf("Dealt card %v should not also be set aside", card)
+		}
+	}
+	if total := len(cards) + len(aside) + deck.Size(); total != 52 {
+		t.Errorf("Expected 52 cards accounted for, got %d", total)
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	deck := NewDeck()
+	deck.Shuffle()
+	deck.Sort()
+
+	cards := deck.Cards()
+	for i := 1; i < len(cards); i++ {
+		if cards[i-1].SortKey() >= cards[i].SortKey() {
+			t.Errorf("SortKey ordering disagrees with Sort at %v, %v", cards[i-1], cards[i])
+		}
+	}
+
+	if NewCard(Spades, King).SortKey() >= NewCard(Hearts, Ace).SortKey() {
+		t.Error("Suit should take precedence over rank in SortKey")
+	}
+}
+
+func TestNewDeckRange(t *testing.T) {
+	deck, err := NewDeckRange(Nine, King, Hearts, Diamonds)
+	if err != nil {
+		t.Fatalf("Unexpected error building deck: %v", err)
+	}
+
+	if deck.Size() != 10 {
+		t.Errorf("Expected deck size to be 10, got %d", deck.Size())
+	}
+
+	for _, card := range deck.Cards() {
+		if !card.IsRed() || card.Rank < Nine {
+			t.Errorf("Unexpected card %v in range deck", card)
+		}
+	}
+
+	full, err := NewDeckRange(Ace, King)
+	if err != nil || full.Size() != 52 {
+		t.Errorf("Expected full deck with no suits given, got %d cards (err %v)", full.Size(), err)
+	}
+
+	if _, err := NewDeckRange(King, Two); err == nil {
+		t.Error("Expected error when min rank exceeds max rank")
+	}
+
+	if _, err := NewDeckRange(Two, King, Suit(9)); err == nil {
+		t.Error("Expected error for invalid suit")
+	}
+}
+
+func TestIter(t *testing.T) {
+	deck := NewDeck()
+	snapshot := deck.Iter()
+
+	deck.Shuffle()
+	deck.DealN(10)
+
+	count := 0
+	expected := NewDeck().Cards()
+	for i, card := range snapshot {
+		if card != expected[i] {
+			t.Errorf("Snapshot changed at position %d: got %v, want %v", i, card, expected[i])
+		}
+		count++
+	}
+
+	if count != 52 {
+		t.Errorf("Expected to iterate 52 cards, got %d", count)
+	}
+}
+
+func TestChannel(t *testing.T) {
+	deck := NewDeck()
+	ch := deck.Channel(context.Background())
+	deck.Shuffle()
+	deck.DealN(10)
+
+	var received []Card
+	for card := range ch {
+		received = append(received, card)
+	}
+	expected := NewDeck().Cards()
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %d cards, got %d", len(expected), len(received))
+	}
+	for i, card := range received {
+		if card != expected[i] {
+			t.Errorf("Snapshot changed at position %d: got %v, want %v", i, card, expected[i])
+		}
+	}
+	if deck.Size() != 42 {
+		t.Errorf("Expected Channel not to deal cards, deck has %d", deck.Size())
+	}
+}
+
+func TestChannelCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := NewDeck().Channel(ctx)
+	<-ch
+	cancel()
+
+	count := 0
+	for range ch {
+		count++
+	}
+	if count >= 51 {
+		t.Errorf("Expected cancelation to stop the channel early, got %d more cards", count)
+	}
+}
+
+func TestIsFactoryOrder(t *testing.T) {
+	deck := NewDeck()
+	if !deck.IsFactoryOrder() {
+		t.Error("A new deck should be in factory order")
+	}
+
+	deck.ShuffleWithSeed(4)
+	if deck.IsFactoryOrder() {
+		t.Error("A shuffled deck should not be in factory order")
+	}
+
+	deck.Reset()
+	if !deck.IsFactoryOrder() {
+		t.Error("A reset deck should be in factory order")
+	}
+
+	deck.Deal()
+	if deck.IsFactoryOrder() {
+		t.Error("A deck missing a card should not be in factory order")
+	}
+
+	if NewSpanishDeck().IsFactoryOrder() {
+		t.Error("A Spanish deck should not be in factory order")
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	if !NewDeck().IsSorted() {
+		t.Error("A new deck should be sorted")
+	}
+
+	if !NewEmptyDeck().IsSorted() {
+		t.Error("An empty deck should be sorted")
+	}
+
+	deck := NewDeckFromCards([]Card{NewCard(Hearts, Two), NewCard(Spades, Two)})
+	if deck.IsSorted() {
+		t.Error("Hearts before Spades should not be sorted")
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	deck := NewEmptyDeck()
+	for _, card := range []Card{
+		NewCard(Clubs, Four), NewCard(Spades, King), NewCard(Hearts, Two),
+		NewCard(Spades, Ace), NewCard(Hearts, Two), NewCard(Diamonds, Ten),
+	} {
+		deck.InsertSorted(card)
+		if !deck.IsSorted() {
+			t.Fatalf("Deck not sorted after inserting %v: %v", card, deck.Cards())
+		}
+	}
+
+	if deck.Size() != 6 {
+		t.Errorf("Expected deck size to be 6, got %d", deck.Size())
+	}
+
+	if top, _ := deck.Peek(); top != NewCard(Spades, Ace) {
+		t.Errorf("Expected Ace of Spades on top, got %v", top)
+	}
+}
+
+func TestDealOrReshuffle(t *testing.T) {
+	deck := NewDeckFromCards([]Card{NewCard(Spades, Ace)})
+	discard := NewEmptyDeck()
+
+	card, err := deck.DealOrReshuffle(discard)
+	if err != nil || card != NewCard(Spades, Ace) {
+		t.Fatalf("Expected Ace of Spades, got %v (err %v)", card, err)
+	}
+	discard.AddCard(card)
+	discard.AddCard(NewCard(Hearts, Two))
+
+	if _, err := deck.DealOrReshuffle(discard); err != nil {
+		t.Fatalf("Unexpected error reshuffling discard: %v", err)
+	}
+
+	if deck.Reshuffles() != 1 {
+		t.Errorf("Expected 1 reshuffle, got %d", deck.Reshuffles())
+	}
+	if !discard.IsEmpty() {
+		t.Errorf("Discard should be empty after reshuffle, got %d cards", discard.Size())
+	}
+	if deck.Size() != 1 {
+		t.Errorf("Expected 1 card left in deck, got %d", deck.Size())
+	}
+
+	deck.Deal()
+	if _, err := deck.DealOrReshuffle(discard); err == nil {
+		t.Error("Expected error when both piles are empty")
+	}
+
+	deck.Clear()
+	if deck.Reshuffles() != 0 {
+		t.Error("Clear should clear the reshuffle count")
+	}
+
+	discard.AddCards(NewDeck().Cards())
+	deck.DealOrReshuffle(discard)
+	deck.Reset()
+	if deck.Reshuffles() != 0 {
+		t.Error("Reset should clear the reshuffle count")
+	}
+}
+
+func TestShortStringWith(t *testing.T) {
+	card := NewCard(Hearts, Ace)
+	if got := card.ShortStringWith(ASCIISymbols); got != "Ah" {
+		t.Errorf("Expected Ah, got %s", got)
+	}
+
+	if got := NewCard(Clubs, Ten).ShortStringWith(ASCIISymbols); got != "10c" {
+		t.Errorf("Expected 10c, got %s", got)
+	}
+
+	for _, c := range NewDeck().Cards() {
+		if c.ShortStringWith(UnicodeSymbols) != c.ShortString() {
+			t.Errorf("UnicodeSymbols should match ShortString for %v", c)
+		}
+	}
+
+	custom := SymbolSet{Spades: "S", Hearts: "H", Diamonds: "D", Clubs: "C"}
+	if got := NewCard(Diamonds, Queen).ShortStringWith(custom); got != "QD" {
+		t.Errorf("Expected QD, got %s", got)
+	}
+}
+
+func TestUndeal(t *testing.T) {
+	deck := NewDeck()
+	deck.ShuffleWithSeed(3)
+	original := deck.Cards()
+
+	hand, _ := deck.DealN(5)
+	deck.Undeal(hand, true)
+
+	cards := deck.Cards()
+	for i := range original {
+		if cards[i] != original[i] {
+			t.Fatalf("Expected %v at position %d after undeal, got %v", original[i], i, cards[i])
+		}
+	}
+
+	card, _ := deck.Deal()
+	deck.Undeal([]Card{card}, false)
+	if bottom := deck.Cards()[51]; bottom != card {
+		t.Errorf("Expected %v on the bottom, got %v", card, bottom)
+	}
+}
+
+func TestDealDirectional(t *testing.T) {
+	// Players 0-3 sit clockwise; cards are dealt A, 2, 3, ... of Spades
+	tests := []struct {
+		clockwise bool
+		start     int
+		first     [4]Rank // first card each player receives
+	}{
+		{true, 0, [4]Rank{Ace, Two, Three, Four}},
+		{true, 2, [4]Rank{Three, Four, Ace, Two}},
+		{false, 0, [4]Rank{Ace, Four, Three, Two}},
+		{false, 1, [4]Rank{Two, Ace, Four, Three}},
+	}
+
+	for _, tt := range tests {
+		hands, err := NewDeck().DealDirectional(4, 2, tt.clockwise, tt.start)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for p, hand := range hands {
+			if len(hand) != 2 {
+				t.Errorf("Expected player %d to have 2 cards, got %d", p, len(hand))
+				continue
+			}
+			// Each player's second card comes one round of four later
+			if hand[0].Rank != tt.first[p] || hand[1].Rank != tt.first[p]+4 {
+				t.Errorf("clockwise=%v start=%d: unexpected hand for player %d: %v", tt.clockwise, tt.start, p, hand)
+			}
+		}
+	}
+
+	if _, err := NewDeck().DealDirectional(4, 2, true, 4); err == nil {
+		t.Error("Expected error for a start player out of range")
+	}
+	if _, err := NewDeck().DealDirectional(0, 2, true, 0); err == nil {
+		t.Error("Expected error with no players")
+	}
+	if _, err := NewDeck().DealDirectional(6, 9, false, 0); err == nil {
+		t.Error("Expected error dealing more cards than the deck holds")
+	}
+}
+
+func TestDealTournament(t *testing.T) {
+	first, err := NewDeck().DealTournament(6, 2, 47)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A table that has already played other hands deals the same cards
+	table := NewDeck()
+	table.DealN(20)
+	table.Shuffle()
+	second, err := table.DealTournament(6, 2, 47)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Errorf("Expected the same seed to deal the same hands, got %v and %v", first, second)
+	}
+	if table.Size() != 40 {
+		t.Errorf("Expected 40 cards left after the deal, got %d", table.Size())
+	}
+
+	other, _ := NewDeck().DealTournament(6, 2, 48)
+	if fmt.Sprint(first) == fmt.Sprint(other) {
+		t.Error("Expected a different seed to deal different hands")
+	}
+
+	if _, err := NewDeck().DealTournament(6, 9, 47); err == nil {
+		t.Error("Expected error dealing more cards than the deck holds")
+	}
+}
+
+func TestDealCursorNoAliasing(t *testing.T) {
+	deck := NewDeck()
+	hand, _ := deck.DealN(5)
+	first := hand[0]
+
+	// Reset reuses storage; previously dealt cards must not change
+	deck.ShuffleWithSeed(9)
+	deck.Reset()
+	deck.ShuffleWithSeed(10)
+	if hand[0] != first {
+		t.Errorf("Dealt hand changed after reset: got %v, want %v", hand[0], first)
+	}
+
+	// Adding cards after dealing reuses space without disturbing the deck
+	deck = NewDeck()
+	deck.DealN(50)
+	remaining := deck.Cards()
+	deck.AddCards([]Card{NewCard(Hearts, Ace), NewCard(Hearts, Two), NewCard(Hearts, Three)})
+
+	cards := deck.Cards()
+	expected := append(remaining, NewCard(Hearts, Ace), NewCard(Hearts, Two), NewCard(Hearts, Three))
+	if len(cards) != len(expected) {
+		t.Fatalf("Expected %d cards, got %d", len(expected), len(cards))
+	}
+	for i := range expected {
+		if cards[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, cards[i])
+		}
+	}
+}
+
+func TestReturnedSlicesDoNotAlias(t *testing.T) {
+	// Each case takes a fresh deck, returns slices from one API call and
+	// scribbles over them; the deck must be unaffected
+	tests := []struct {
+		name string
+		call func(d *Deck) [][]Card
+	}{
+		{"Cards", func(d *Deck) [][]Card { return [][]Card{d.Cards()} }},
+		{"Iter", func(d *Deck) [][]Card { return [][]Card{d.Iter()} }},
+		{"PeekN", func(d *Deck) [][]Card { c, _ := d.PeekN(5); return [][]Card{c} }},
+		{"PeekCyclic", func(d *Deck) [][]Card { return [][]Card{d.PeekCyclic(50, 5)} }},
+		{"TopK", func(d *Deck) [][]Card {
+			c, _ := d.TopK(3, func(a, b Card) bool { return a.Rank > b.Rank })
+			return [][]Card{c}
+		}},
+		{"DealN", func(d *Deck) [][]Card { c, _ := d.DealN(5); return [][]Card{c} }},
+		{"DealRandomN", func(d *Deck) [][]Card { c, _ := d.DealRandomN(5); return [][]Card{c} }},
+		{"DealMatching", func(d *Deck) [][]Card {
+			c, _ := d.DealMatching(2, Card.IsRed)
+			return [][]Card{c, d.SetAside()}
+		}},
+		{"DealtHistory", func(d *Deck) [][]Card {
+			d.EnableDealtHistory()
+			d.DealN(3)
+			return [][]Card{d.DealtHistory()}
+		}},
+		{"DealUniqueHands", func(d *Deck) [][]Card { h, _ := d.DealUniqueHands([]int{2, 2, 2}); return h }},
+		{"DealCategorized", func(d *Deck) [][]Card { _, h, _ := d.DealCategorized(3, 2); return h }},
+		{"DealWithStock", func(d *Deck) [][]Card {
+			h, stock, _ := d.DealWithStock(2, 3)
+			*d = *stock
+			return h
+		}},
+		{"Combinations", func(d *Deck) [][]Card { return d.Combinations(2) }},
+		{"Diff", func(d *Deck) [][]Card {
+			missing, extra := d.Diff(NewEmptyDeck())
+			return [][]Card{missing, extra}
+		}},
+	}
+
+	for _, tt := range tests {
+		deck := NewDeck()
+		deck.ShuffleWithSeed(5)
+		slices := tt.call(deck)
+		before := deck.Cards()
+
+		for _, s := range slices {
+			for i := range s {
+				s[i] = Card{}
+			}
+			// Appending must not write into storage shared with the deck
+			_ = append(s, Card{}, Card{}, Card{})
+		}
+
+		after := deck.Cards()
+		if len(after) != len(before) {
+			t.Errorf("%s: deck size changed from %d to %d", tt.name, len(before), len(after))
+			continue
+		}
+		for i := range before {
+			if after[i] != before[i] {
+				t.Errorf("%s: modifying returned cards changed the deck at position %d", tt.name, i)
+				break
+			}
+		}
+	}
+
+	// Slices passed in are copied
+	cards := NewDeck().Cards()
+	deck := NewDeckFromCards(cards)
+	deck.AddCards(cards[:2])
+	cards[0] = Card{}
+	if c, _ := deck.Peek(); c != NewCard(Spades, Ace) {
+		t.Error("Modifying a slice passed to NewDeckFromCards changed the deck")
+	}
+}
+
+func TestResetDoesNotAllocate(t *testing.T) {
+	deck := NewDeck()
+	allocs := testing.AllocsPerRun(100, func() {
+		for !deck.IsEmpty() {
+			deck.Deal()
+		}
+		deck.Reset()
+	})
+
+	if allocs != 0 {
+		t.Errorf("Expected dealing and resetting to reuse storage, got %v allocations", allocs)
+	}
+}
+
+func TestView(t *testing.T) {
+	deck := NewDeck()
+	deck.DealN(2)
+
+	view := deck.View()
+	cards := deck.Cards()
+	if len(view) != len(cards) {
+		t.Fatalf("Expected view of %d cards, got %d", len(cards), len(view))
+	}
+	for i := range cards {
+		if view[i] != cards[i] {
+			t.Errorf("Expected %v at position %d, got %v", cards[i], i, view[i])
+		}
+	}
+
+	// Appending to the view must not write into the deck's spare capacity
+	_ = append(view, NewCard(Hearts, Ace))
+	if deck.Size() != 50 {
+		t.Errorf("Expected deck to keep 50 cards, got %d", deck.Size())
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = deck.View()
+	})
+	if allocs != 0 {
+		t.Errorf("Expected View not to allocate, got %v allocations", allocs)
+	}
+}
+
+func TestCap(t *testing.T) {
+	deck := NewEmptyDeck()
+	deck.AddCards(NewDeck().Cards())
+	if deck.Cap() < 52 {
+		t.Fatalf("Expected capacity of at least 52, got %d", deck.Cap())
+	}
+
+	capacity := deck.Cap()
+	deck.DealN(10)
+	if deck.Cap() != capacity {
+		t.Errorf("Expected dealing to keep capacity %d, got %d", capacity, deck.Cap())
+	}
+
+	// Refilling to capacity reuses the dealt space
+	deck.AddCards(make([]Card, capacity-deck.Size()))
+	if deck.Cap() != capacity {
+		t.Errorf("Expected refilling to keep capacity %d, got %d", capacity, deck.Cap())
+	}
+
+	// Growing a shoe reallocates rarely
+	shoe := NewEmptyDeck()
+	grows := 0
+	for i := 0; i < 8; i++ {
+		before := shoe.Cap()
+		shoe.AddCards(NewDeck().Cards())
+		if shoe.Cap() != before {
+			grows++
+		}
+	}
+	if grows > 4 {
+		t.Errorf("Expected at most 4 reallocations building an 8-deck shoe, got %d", grows)
+	}
+}
+
+func TestSortStability(t *testing.T) {
+	// A two-deck shoe, so every card has a duplicate
+	shoe := NewDeck()
+	shoe.AddCards(NewDeck().Cards())
+	shoe.ShuffleWithSeed(21)
+
+	// Sorting by suit alone leaves many ties; they must keep shoe order
+	cards := shoe.Cards()
+	bySuit := shoe.Cards()
+	sortStable(bySuit, func(a, b Card) bool { return a.Suit < b.Suit })
+
+	i := 0
+	for suit := Spades; suit <= Clubs; suit++ {
+		for _, card := range cards {
+			if card.Suit != suit {
+				continue
+			}
+			if bySuit[i] != card {
+				t.Fatalf("Expected %v at position %d, got %v", card, i, bySuit[i])
+			}
+			i++
+		}
+	}
+
+	// Each sort gives the same result however the shoe started
+	for _, mode := range []SortMode{SuitThenRank, RankThenSuit} {
+		a := NewDeckFromCards(cards)
+		b := NewDeckFromCards(cards)
+		b.ShuffleWithSeed(22)
+		a.SortBy(mode)
+		b.SortBy(mode)
+		if fmt.Sprint(a.Cards()) != fmt.Sprint(b.Cards()) {
+			t.Errorf("Expected sort mode %d to be deterministic", mode)
+		}
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	shoe := NewEmptyDeck()
+	for i := 0; i < 4; i++ {
+		shoe.AddCards(NewDeck().Cards())
+	}
+	shoe.ShuffleWithSeed(31)
+
+	sorted := NewDeckFromCards(shoe.Cards())
+	sorted.Sort()
+	shoe.SortStable()
+
+	cards := shoe.Cards()
+	for i, card := range sorted.Cards() {
+		if cards[i] != card {
+			t.Fatalf("Expected %v at position %d, got %v", card, i, cards[i])
+		}
+	}
+	for i := 0; i < len(cards); i++ {
+		if cards[i] != CardFromIndex(uint8(i/4)) {
+			t.Fatalf("Expected four copies of each card in order, got %v at position %d", cards[i], i)
+		}
+	}
+}
+
+func TestSortRadix(t *testing.T) {
+	shoe := NewEmptyDeck()
+	for i := 0; i < 8; i++ {
+		shoe.AddCards(NewDeck().Cards())
+	}
+	shoe.ShuffleWithSeed(5)
+	shoe.SortRadix()
+
+	if !shoe.IsSorted() {
+		t.Error("Shoe should be sorted after SortRadix")
+	}
+	if shoe.Size() != 416 || shoe.Count(NewCard(Hearts, Ace)) != 8 {
+		t.Error("SortRadix should preserve the shoe's cards")
+	}
+
+	// Invalid cards fall back to a comparison sort
+	deck := NewDeckFromCards([]Card{NewCard(Hearts, Two), {}, NewCard(Spades, King)})
+	deck.SortRadix()
+	if !deck.IsSorted() || deck.Size() != 3 {
+		t.Errorf("Expected sorted deck with invalid card, got %v", deck.Cards())
+	}
+}
+
+// bubbleSort is the original Sort implementation, kept for benchmarking
+func bubbleSort(cards []Card) {
+	for i := 0; i < len(cards)-1; i++ {
+		for j := 0; j < len(cards)-i-1; j++ {
+			if cards[j].SortKey() > cards[j+1].SortKey() {
+				cards[j], cards[j+1] = cards[j+1], cards[j]
+			}
+		}
+	}
+}
+
+func benchmarkSort(b *testing.B, sortFn func(d *Deck)) {
+	shoe := NewEmptyDeck()
+	for i := 0; i < 8; i++ {
+		shoe.AddCards(NewDeck().Cards())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		shoe.ShuffleWithSeed(int64(i))
+		b.StartTimer()
+		sortFn(shoe)
+	}
+}
+
+func BenchmarkSortBubble(b *testing.B) {
+	benchmarkSort(b, func(d *Deck) { bubbleSort(d.cards()) })
+}
+
+func BenchmarkSortSlice(b *testing.B) {
+	benchmarkSort(b, func(d *Deck) {
+		cards := d.cards()
+		sort.Slice(cards, func(i, j int) bool { return cards[i].SortKey() < cards[j].SortKey() })
+	})
+}
+
+func BenchmarkSortRadix(b *testing.B) {
+	benchmarkSort(b, func(d *Deck) { d.SortRadix() })
+}
@@ -0,0 +1,59 @@
+package deck
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash/crc32"
+)
+
+// MarshalBinary encodes the cards in the deck, top first, as one
+// (suit, rank) byte pair per card. It implements encoding.BinaryMarshaler.
+func (d *Deck) MarshalBinary() ([]byte, error) {
+	return d.appendBinary(make([]byte, 0, 2*d.Size())), nil
+}
+
+// UnmarshalBinary replaces the cards in the deck with those decoded from data
+// produced by MarshalBinary. It implements encoding.BinaryUnmarshaler.
+func (d *Deck) UnmarshalBinary(data []byte) error {
+	if len(data)%2 != 0 {
+		return errors.New("invalid deck encoding length")
+	}
+
+	cards := make([]Card, 0, len(data)/2)
+	for i := 0; i < len(data); i += 2 {
+		card := NewCard(Suit(data[i]), Rank(data[i+1]))
+		if !card.IsValid() {
+			return errors.New("invalid card in deck encoding")
+		}
+		cards = append(cards, card)
+	}
+
+	d.setCards(cards)
+	d.reindex()
+	return nil
+}
+
+// Checksum returns the CRC-32 (IEEE) checksum of the deck's binary encoding.
+// Any change to the cards or their order changes the checksum with high
+// probability, so it can be used to verify deck state sent between a client
+// and server.
+func (d *Deck) Checksum() uint32 {
+	return crc32.ChecksumIEEE(d.appendBinary(make([]byte, 0, 2*d.Size())))
+}
+
+// Hash returns the hex-encoded SHA-256 digest of the deck's binary encoding.
+// Unlike Checksum it is collision resistant, so it suits audit logs where the
+// deck state must not be forgeable.
+func (d *Deck) Hash() string {
+	sum := sha256.Sum256(d.appendBinary(make([]byte, 0, 2*d.Size())))
+	return hex.EncodeToString(sum[:])
+}
+
+// appendBinary appends the binary encoding of the deck's cards to dst
+func (d *Deck) appendBinary(dst []byte) []byte {
+	for _, card := range d.cards() {
+		dst = append(dst, byte(card.Suit), byte(card.Rank))
+	}
+	return dst
+}
@@ -0,0 +1,73 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	deck := NewDeck()
+	deck.ShuffleWithSeed(7)
+
+	data, err := deck.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling deck: %v", err)
+	}
+	if len(data) != 104 {
+		t.Errorf("Expected 104 bytes, got %d", len(data))
+	}
+
+	decoded := NewEmptyDeck()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error unmarshaling deck: %v", err)
+	}
+
+	original := deck.Cards()
+	for i, card := range decoded.Cards() {
+		if card != original[i] {
+			t.Errorf("Expected %v at position %d, got %v", original[i], i, card)
+		}
+	}
+
+	if err := decoded.UnmarshalBinary([]byte{0}); err == nil {
+		t.Error("Expected error for odd-length encoding")
+	}
+
+	if err := decoded.UnmarshalBinary([]byte{0, 0}); err == nil {
+		t.Error("Expected error for invalid card")
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	deck := NewDeck()
+	checksum := deck.Checksum()
+
+	if NewDeck().Checksum() != checksum {
+		t.Error("Identical decks should have identical checksums")
+	}
+
+	// Swap two cards
+	cards := deck.Cards()
+	cards[10], cards[11] = cards[11], cards[10]
+	swapped := NewDeckFromCards(cards)
+
+	if swapped.Checksum() == checksum {
+		t.Error("Swapping two cards should change the checksum")
+	}
+}
+
+func TestHash(t *testing.T) {
+	deck := NewDeck()
+	hash := deck.Hash()
+
+	if len(hash) != 64 {
+		t.Errorf("Expected a 64 character hex digest, got %d characters", len(hash))
+	}
+	if NewDeck().Hash() != hash {
+		t.Error("Identical decks should have identical hashes")
+	}
+
+	deck.ShuffleWithSeed(1)
+	if deck.Hash() == hash {
+		t.Error("Shuffling should change the hash")
+	}
+}
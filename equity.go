@@ -0,0 +1,136 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+)
+
+// equityCheckInterval is how many trials EquityContext runs between checks
+// for cancelation
+const equityCheckInterval = 1024
+
+// EquityContext estimates each hand's share of the pot in community-card
+// poker by Monte Carlo simulation. Each trial completes the board to five
+// cards with a random draw from this deck, less any cards already held in
+// hands or on board and any marked dead with SetDead, and splits the pot
+// between the best hands. The result holds each hand's average share, so the
+// values sum to 1.
+//
+// ctx is checked before the first trial and then every 1024 trials; on
+// cancelation EquityContext returns ctx.Err() and no estimate. The deck is
+// not modified.
+func (d *Deck) EquityContext(ctx context.Context, hands [][]Card, board []Card, trials int) ([]float64, error) {
+	if len(hands) == 0 {
+		return nil, errors.New("at least one hand is required")
+	}
+	if len(board) > 5 {
+		return nil, errors.New("board cannot have more than 5 cards")
+	}
+	if trials <= 0 {
+		return nil, errors.New("number of trials must be positive")
+	}
+
+	known := append([]Card(nil), board...)
+	for _, hand := range hands {
+		known = append(known, hand...)
+	}
+	stub := NewDeckFromCards(d.live()).Subtract(NewDeckFromCards(known)).cards()
+	need := 5 - len(board)
+	if need > len(stub) {
+		return nil, errors.New("not enough cards in deck")
+	}
+
+	full := make([][]Card, len(hands))
+	for i, hand := range hands {
+		full[i] = make([]Card, len(hand), len(hand)+5)
+		copy(full[i], hand)
+		full[i] = append(full[i], board...)
+	}
+
+	equity := make([]float64, len(hands))
+	for trial := 0; trial < trials; trial++ {
+		if trial%equityCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		// Partial Fisher-Yates: the first need positions complete the board
+		for i := 0; i < need; i++ {
+			j := i + rand.Intn(len(stub)-i)
+			stub[i], stub[j] = stub[j], stub[i]
+		}
+		for i, hand := range hands {
+			full[i] = append(full[i][:len(hand)+len(board)], stub[:need]...)
+		}
+
+		winners, _ := Winners(full)
+		for _, w := range winners {
+			equity[w] += 1 / float64(len(winners))
+		}
+	}
+
+	for i := range equity {
+		equity[i] /= float64(trials)
+	}
+	return equity, nil
+}
+
+// HandStrength estimates the chance that hole wins against the given number
+// of opponents holding random two-card hands, by Monte Carlo simulation. Each
+// trial deals the opponents and completes the board to five cards from a
+// standard deck less hole and board, drawing from r, or from the
+// package-level source if r is nil. Split pots count as a fractional win, so
+// the result is hole's average share of the pot.
+func HandStrength(hole, board []Card, opponents, trials int, r Intner) (float64, error) {
+	if opponents <= 0 {
+		return 0, errors.New("number of opponents must be positive")
+	}
+	if len(board) > 5 {
+		return 0, errors.New("board cannot have more than 5 cards")
+	}
+	if trials <= 0 {
+		return 0, errors.New("number of trials must be positive")
+	}
+	if r == nil {
+		r = globalRand{}
+	}
+
+	known := append(append([]Card(nil), hole...), board...)
+	stub := NewDeck().Subtract(NewDeckFromCards(known)).cards()
+	need := 5 - len(board)
+	draw := need + 2*opponents
+	if draw > len(stub) {
+		return 0, errors.New("not enough cards in deck")
+	}
+
+	hands := make([][]Card, opponents+1)
+	for i := range hands {
+		hands[i] = make([]Card, 0, 7)
+	}
+
+	strength := 0.0
+	for trial := 0; trial < trials; trial++ {
+		// Partial Fisher-Yates: the first draw positions hold the new cards
+		for i := 0; i < draw; i++ {
+			j := i + r.Intn(len(stub)-i)
+			stub[i], stub[j] = stub[j], stub[i]
+		}
+
+		runout := stub[:need]
+		hands[0] = append(append(append(hands[0][:0], hole...), board...), runout...)
+		for i := 1; i <= opponents; i++ {
+			dealt := stub[need+2*(i-1) : need+2*i]
+			hands[i] = append(append(append(hands[i][:0], dealt...), board...), runout...)
+		}
+
+		winners, _ := Winners(hands)
+		for _, w := range winners {
+			if w == 0 {
+				strength += 1 / float64(len(winners))
+			}
+		}
+	}
+	return strength / float64(trials), nil
+}
@@ -0,0 +1,112 @@
+package deck
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEquityContext(t *testing.T) {
+	aces, _ := ParsePokerNotation("AsAh")
+	kings, _ := ParsePokerNotation("KsKh")
+
+	// Aces are about an 82% favorite over Kings preflop
+	equity, err := NewDeck().EquityContext(context.Background(), [][]Card{aces, kings}, nil, 20000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(equity[0]-0.82) > 0.02 {
+		t.Errorf("Expected Aces to have about 82%% equity, got %.3f", equity[0])
+	}
+	if math.Abs(equity[0]+equity[1]-1) > 1e-9 {
+		t.Errorf("Expected equities to sum to 1, got %v", equity)
+	}
+
+	// With a complete board the result is exact: the board plays, so split
+	board, _ := ParsePokerNotation("2c3d4h5s6c")
+	equity, err = NewDeck().EquityContext(context.Background(), [][]Card{aces, kings}, board, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equity[0] != 0.5 || equity[1] != 0.5 {
+		t.Errorf("Expected a split pot, got %v", equity)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewDeck().EquityContext(ctx, [][]Card{aces, kings}, nil, 1000); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if _, err := NewDeck().EquityContext(context.Background(), nil, nil, 10); err == nil {
+		t.Error("Expected error without hands")
+	}
+}
+
+func TestEquityContextDeadCards(t *testing.T) {
+	// Only one card to come; with every other Spade dead, Spades can't flush
+	hands := make([][]Card, 2)
+	hands[0], _ = ParsePokerNotation("AsKs")
+	hands[1], _ = ParsePokerNotation("2h2d")
+	board, _ := ParsePokerNotation("QsJs3c7d")
+
+	deck := NewDeck()
+	var dead []Card
+	for _, card := range deck.Cards() {
+		if card.Suit == Spades || card.Rank == Ten {
+			dead = append(dead, card)
+		}
+	}
+	deck.SetDead(dead)
+
+	// With no Spade or Ten to come, only an Ace or King (6 outs) beats the
+	// Twos; 32 cards are neither dead nor held
+	equity, err := deck.EquityContext(context.Background(), hands, board, 20000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(equity[0]-6.0/32) > 0.02 {
+		t.Errorf("Expected about %.3f equity, got %.3f", 6.0/32, equity[0])
+	}
+}
+
+func TestHandStrength(t *testing.T) {
+	aces, _ := ParsePokerNotation("AsAh")
+
+	// Aces win about 85% of the time heads up
+	strength, err := HandStrength(aces, nil, 1, 20000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(strength-0.85) > 0.02 {
+		t.Errorf("Expected Aces to win about 85%% heads up, got %.3f", strength)
+	}
+
+	// More opponents make any hand weaker
+	crowded, _ := HandStrength(aces, nil, 5, 20000, rand.New(rand.NewSource(1)))
+	if crowded >= strength {
+		t.Errorf("Expected Aces to be weaker against 5 opponents, got %.3f vs %.3f", crowded, strength)
+	}
+
+	// The nuts on a complete board always win, whatever the draw
+	nuts, _ := ParsePokerNotation("AsKs")
+	board, _ := ParsePokerNotation("QsJsTs2c3d")
+	strength, err = HandStrength(nuts, board, 3, 10, SortedSource{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strength != 1 {
+		t.Errorf("Expected a royal flush to always win, got %v", strength)
+	}
+
+	if _, err := HandStrength(aces, nil, 0, 10, nil); err == nil {
+		t.Error("Expected error without opponents")
+	}
+	if _, err := HandStrength(aces, nil, 1, 0, nil); err == nil {
+		t.Error("Expected error without trials")
+	}
+	if _, err := HandStrength(aces, nil, 23, 10, nil); err == nil {
+		t.Error("Expected error dealing more cards than the deck holds")
+	}
+}
@@ -69,12 +69,7 @@ func main() {
 
 	// Create a custom hand
 	fmt.Println("\nCreating a custom hand (poker royal flush):")
-	royalFlush := deck.NewEmptyDeck()
-	royalFlush.AddCard(deck.NewCard(deck.Spades, deck.Ten))
-	royalFlush.AddCard(deck.NewCard(deck.Spades, deck.Jack))
-	royalFlush.AddCard(deck.NewCard(deck.Spades, deck.Queen))
-	royalFlush.AddCard(deck.NewCard(deck.Spades, deck.King))
-	royalFlush.AddCard(deck.NewCard(deck.Spades, deck.Ace))
+	royalFlush := deck.NewDeckFromCards(deck.RoyalFlushHand(deck.Spades))
 
 	cards := royalFlush.Cards()
 	for i, card := range cards {
@@ -0,0 +1,79 @@
+package deck
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+)
+
+// fairStream is the deterministic byte stream behind ShuffleProvablyFair
+type fairStream struct {
+	serverSeed string
+	clientSeed string
+	nonce      int
+	round      int
+	buf        []byte
+}
+
+// next returns the next 4 bytes of the stream as a big-endian uint32
+func (s *fairStream) next() uint32 {
+	if len(s.buf) < 4 {
+		mac := hmac.New(sha256.New, []byte(s.serverSeed))
+		mac.Write([]byte(s.clientSeed + ":" + strconv.Itoa(s.nonce) + ":" + strconv.Itoa(s.round)))
+		s.buf = append(s.buf, mac.Sum(nil)...)
+		s.round++
+	}
+	v := binary.BigEndian.Uint32(s.buf)
+	s.buf = s.buf[4:]
+	return v
+}
+
+// intn returns a uniform number in [0, n), rejecting values below 2^32 mod n
+func (s *fairStream) intn(n uint32) uint32 {
+	threshold := -n % n
+	for {
+		if v := s.next(); v >= threshold {
+			return v % n
+		}
+	}
+}
+
+// ProvablyFairBytes returns the first n bytes of the stream used by
+// ShuffleProvablyFair, so a player can check it independently. It returns an
+// empty slice if n is not positive.
+func ProvablyFairBytes(serverSeed, clientSeed string, nonce, n int) []byte {
+	if n <= 0 {
+		return []byte{}
+	}
+
+	s := &fairStream{serverSeed: serverSeed, clientSeed: clientSeed, nonce: nonce}
+	out := make([]byte, 0, n+4)
+	for len(out) < n {
+		out = binary.BigEndian.AppendUint32(out, s.next())
+	}
+	return out[:n]
+}
+
+// ShuffleProvablyFair shuffles the deck deterministically from a server seed,
+// client seed and nonce so that a player can verify the shuffle once the
+// server seed is revealed. The algorithm is:
+//
+//  1. The byte stream is the concatenation of HMAC-SHA256 blocks keyed by
+//     serverSeed over the messages "clientSeed:nonce:0", "clientSeed:nonce:1",
+//     and so on (see ProvablyFairBytes).
+//  2. The stream is read as consecutive big-endian uint32 values.
+//  3. A Fisher-Yates shuffle runs from the bottom of the deck (i = n-1) up to
+//     i = 1, swapping card i with card j, where j is the next value v modulo
+//     i+1. Values v < 2^32 mod (i+1) are skipped so j is unbiased.
+//
+// The same inputs applied to the same starting order always produce the same
+// result, which can be inspected with Cards.
+func (d *Deck) ShuffleProvablyFair(serverSeed, clientSeed string, nonce int) {
+	s := &fairStream{serverSeed: serverSeed, clientSeed: clientSeed, nonce: nonce}
+	cards := d.cards()
+	for i := len(cards) - 1; i > 0; i-- {
+		j := int(s.intn(uint32(i + 1)))
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+}
@@ -0,0 +1,63 @@
+package deck
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestProvablyFairBytes(t *testing.T) {
+	mac := hmac.New(sha256.New, []byte("server"))
+	mac.Write([]byte("client:1:0"))
+	first := mac.Sum(nil)
+
+	mac = hmac.New(sha256.New, []byte("server"))
+	mac.Write([]byte("client:1:1"))
+	second := mac.Sum(nil)
+
+	stream := ProvablyFairBytes("server", "client", 1, 40)
+	expected := append(first, second[:8]...)
+	if !bytes.Equal(stream, expected) {
+		t.Errorf("Stream does not match HMAC-SHA256 blocks:\n got %x\nwant %x", stream, expected)
+	}
+
+	for _, n := range []int{0, -1, -5} {
+		if got := ProvablyFairBytes("server", "client", 1, n); len(got) != 0 {
+			t.Errorf("Expected no bytes for n = %d, got %x", n, got)
+		}
+	}
+}
+
+func TestShuffleProvablyFair(t *testing.T) {
+	deck1 := NewDeck()
+	deck2 := NewDeck()
+	deck1.ShuffleProvablyFair("server-seed", "client-seed", 42)
+	deck2.ShuffleProvablyFair("server-seed", "client-seed", 42)
+
+	cards1, cards2 := deck1.Cards(), deck2.Cards()
+	for i := range cards1 {
+		if cards1[i] != cards2[i] {
+			t.Fatal("Decks shuffled with the same inputs should be identical")
+		}
+	}
+
+	for _, card := range NewDeck().Cards() {
+		if !deck1.Contains(card) {
+			t.Errorf("Shuffled deck is missing %v", card)
+		}
+	}
+
+	deck3 := NewDeck()
+	deck3.ShuffleProvablyFair("server-seed", "client-seed", 43)
+	same := true
+	for i, card := range deck3.Cards() {
+		if card != cards1[i] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("A different nonce should produce a different order")
+	}
+}
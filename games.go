@@ -0,0 +1,156 @@
+package deck
+
+import (
+	"errors"
+	"sort"
+)
+
+// MatchPairs pulls rank-matched pairs out of a hand, as when laying down
+// pairs in Old Maid or Go Fish. Cards of each rank are paired in hand order,
+// and pairs are returned in the order their first card appears in the hand.
+// An odd card of a rank stays in remaining, so three of a kind yields one pair
+// and one leftover card. Four of a kind yields two pairs, unless keepBooks is
+// set, in which case all four cards stay in remaining as a complete book.
+// remaining keeps the hand's order.
+func MatchPairs(hand []Card, keepBooks bool) (pairs [][2]Card, remaining []Card) {
+	groups := groupByRank(hand)
+	paired := make(map[Card]int)
+	done := make(map[Rank]bool)
+	for _, card := range hand {
+		if done[card.Rank] {
+			continue
+		}
+		done[card.Rank] = true
+
+		group := groups[card.Rank]
+		if keepBooks && len(group) == 4 {
+			continue
+		}
+		for i := 0; i+1 < len(group); i += 2 {
+			pairs = append(pairs, [2]Card{group[i], group[i+1]})
+			paired[group[i]]++
+			paired[group[i+1]]++
+		}
+	}
+
+	for _, card := range hand {
+		if paired[card] > 0 {
+			paired[card]--
+			continue
+		}
+		remaining = append(remaining, card)
+	}
+	return pairs, remaining
+}
+
+// Books finds the ranks for which the hand holds all four suits, as when
+// laying down a completed book in Go Fish. Books are returned in the order
+// their first card appears in the hand. One card of each suit is taken for
+// each book; everything else stays in remaining, in hand order.
+func Books(hand []Card) (books []Rank, remaining []Card) {
+	groups := groupByRank(hand)
+	taken := make(map[Card]bool)
+	for _, card := range hand {
+		group, ok := groups[card.Rank]
+		if !ok {
+			continue
+		}
+		delete(groups, card.Rank)
+
+		suits := make(map[Suit]bool)
+		for _, c := range group {
+			if c.Suit.IsValid() {
+				suits[c.Suit] = true
+			}
+		}
+		if len(suits) < 4 {
+			continue
+		}
+
+		books = append(books, card.Rank)
+		for suit := range suits {
+			taken[NewCard(suit, card.Rank)] = true
+		}
+	}
+
+	for _, card := range hand {
+		if taken[card] {
+			delete(taken, card)
+			continue
+		}
+		remaining = append(remaining, card)
+	}
+	return books, remaining
+}
+
+// LegalPlays returns the cards in hand that may legally be played to a trick
+// in which ledSuit was led. A player holding the led suit must follow suit.
+// A player void in the led suit may play anything, unless mustTrump is set
+// and they hold the trump suit, in which case they must play a trump. The
+// result keeps the hand's order.
+func LegalPlays(hand []Card, ledSuit, trump Suit, mustTrump bool) []Card {
+	if follow := cardsOfSuit(hand, ledSuit); len(follow) > 0 {
+		return follow
+	}
+	if mustTrump {
+		if trumps := cardsOfSuit(hand, trump); len(trumps) > 0 {
+			return trumps
+		}
+	}
+	return append([]Card(nil), hand...)
+}
+
+// cardsOfSuit returns the cards of the given suit in their original order
+func cardsOfSuit(cards []Card, suit Suit) []Card {
+	var matched []Card
+	for _, card := range cards {
+		if card.Suit == suit {
+			matched = append(matched, card)
+		}
+	}
+	return matched
+}
+
+// ArrangeBySuit fans a hand into suit columns, as a bridge player holds their
+// cards. Each column is sorted from highest rank to lowest, with Aces ranked
+// above Kings when aceHigh is set and below Twos otherwise. Suits with no
+// cards are omitted.
+func ArrangeBySuit(cards []Card, aceHigh bool) map[Suit][]Card {
+	columns := make(map[Suit][]Card)
+	for _, card := range cards {
+		columns[card.Suit] = append(columns[card.Suit], card)
+	}
+
+	value := func(r Rank) int {
+		if aceHigh {
+			return r.aceHighValue()
+		}
+		return int(r)
+	}
+	for _, column := range columns {
+		sort.SliceStable(column, func(i, j int) bool {
+			return value(column[i].Rank) > value(column[j].Rank)
+		})
+	}
+	return columns
+}
+
+// DealCribbage deals a two-player cribbage hand: six cards to each player,
+// one at a time, then the starter cut from a random position in the rest of
+// the deck. Choosing which cards go to the crib is up to the players, so crib
+// is returned empty, with room for the four discards.
+func (d *Deck) DealCribbage() (hands [][]Card, crib []Card, starter Card, err error) {
+	if d.Size() < 13 {
+		return nil, nil, Card{}, errors.New("not enough cards in deck")
+	}
+
+	hands, err = d.dealRoundRobin(2, 6)
+	if err != nil {
+		return nil, nil, Card{}, err
+	}
+	cut, err := d.DealRandomN(1)
+	if err != nil {
+		return nil, nil, Card{}, err
+	}
+	return hands, make([]Card, 0, 4), cut[0], nil
+}
@@ -0,0 +1,157 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestMatchPairs(t *testing.T) {
+	hand := []Card{
+		NewCard(Spades, Seven),
+		NewCard(Hearts, Two),
+		NewCard(Hearts, Seven),
+		NewCard(Clubs, Seven),
+		NewCard(Diamonds, Seven),
+		NewCard(Clubs, Two),
+		NewCard(Spades, Nine),
+		NewCard(Hearts, Nine),
+		NewCard(Clubs, Nine),
+	}
+
+	pairs, remaining := MatchPairs(hand, false)
+	if len(pairs) != 4 {
+		t.Fatalf("Expected 4 pairs, got %d: %v", len(pairs), pairs)
+	}
+
+	// Four Sevens form two pairs, first in hand order
+	if pairs[0] != [2]Card{NewCard(Spades, Seven), NewCard(Hearts, Seven)} ||
+		pairs[1] != [2]Card{NewCard(Clubs, Seven), NewCard(Diamonds, Seven)} {
+		t.Errorf("Unexpected pairs of Sevens: %v", pairs[:2])
+	}
+
+	if pairs[2][0].Rank != Two || pairs[3][0].Rank != Nine {
+		t.Errorf("Expected pairs of Twos then Nines, got %v", pairs[2:])
+	}
+
+	// The odd Nine is left over
+	if len(remaining) != 1 || remaining[0] != NewCard(Clubs, Nine) {
+		t.Errorf("Expected Nine of Clubs remaining, got %v", remaining)
+	}
+
+	pairs, remaining = MatchPairs(hand, true)
+	if len(pairs) != 2 {
+		t.Errorf("Expected 2 pairs when keeping books, got %d", len(pairs))
+	}
+	if len(remaining) != 5 {
+		t.Errorf("Expected the book of Sevens and odd Nine to remain, got %v", remaining)
+	}
+}
+
+func TestBooks(t *testing.T) {
+	hand, _ := ParsePokerNotation("8s3h8h3c8cKs3s8d")
+	books, remaining := Books(hand)
+
+	if len(books) != 1 || books[0] != Eight {
+		t.Errorf("Expected one book of Eights, got %v", books)
+	}
+
+	// The three Threes are only a partial book
+	if got := EncodePokerNotation(remaining); got != "3h3cKs3s" {
+		t.Errorf("Expected 3h3cKs3s to remain, got %s", got)
+	}
+
+	books, remaining = Books(hand[:3])
+	if len(books) != 0 || len(remaining) != 3 {
+		t.Errorf("Expected no books, got %v with %v remaining", books, remaining)
+	}
+}
+
+func TestLegalPlays(t *testing.T) {
+	hand := []Card{
+		NewCard(Spades, Ace),
+		NewCard(Hearts, Two),
+		NewCard(Clubs, Nine),
+		NewCard(Hearts, King),
+	}
+
+	// Must follow suit when able
+	plays := LegalPlays(hand, Hearts, Spades, true)
+	if len(plays) != 2 || plays[0] != NewCard(Hearts, Two) || plays[1] != NewCard(Hearts, King) {
+		t.Errorf("Expected only Hearts to be legal, got %v", plays)
+	}
+
+	// Void in the led suit, may play anything
+	plays = LegalPlays(hand, Diamonds, Spades, false)
+	if len(plays) != len(hand) {
+		t.Errorf("Expected all %d cards to be legal, got %v", len(hand), plays)
+	}
+
+	// Void in the led suit and required to trump
+	plays = LegalPlays(hand, Diamonds, Spades, true)
+	if len(plays) != 1 || plays[0] != NewCard(Spades, Ace) {
+		t.Errorf("Expected only the trump to be legal, got %v", plays)
+	}
+
+	// Required to trump but holding none, may play anything
+	plays = LegalPlays(hand, Diamonds, Diamonds, true)
+	if len(plays) != len(hand) {
+		t.Errorf("Expected all %d cards to be legal, got %v", len(hand), plays)
+	}
+}
+
+func TestArrangeBySuit(t *testing.T) {
+	hand, err := ParsePokerNotation("As7h2sKdQs4c9hTdAhJc3d5sKc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	columns := ArrangeBySuit(hand, true)
+	expected := map[Suit]string{
+		Spades:   "AsQs5s2s",
+		Hearts:   "Ah9h7h",
+		Diamonds: "KdTd3d",
+		Clubs:    "KcJc4c",
+	}
+	for suit, want := range expected {
+		if got := EncodePokerNotation(columns[suit]); got != want {
+			t.Errorf("Expected %s column %s, got %s", suit, want, got)
+		}
+	}
+
+	columns = ArrangeBySuit(hand, false)
+	if got := EncodePokerNotation(columns[Spades]); got != "Qs5s2sAs" {
+		t.Errorf("Expected Aces low column Qs5s2sAs, got %s", got)
+	}
+
+	if _, ok := ArrangeBySuit(hand[:1], true)[Hearts]; ok {
+		t.Error("Expected empty suits to be omitted")
+	}
+}
+
+func TestDealCribbage(t *testing.T) {
+	deck := NewDeck()
+	hands, crib, starter, err := deck.DealCribbage()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(hands) != 2 || len(hands[0]) != 6 || len(hands[1]) != 6 {
+		t.Fatalf("Expected two hands of six cards, got %v", hands)
+	}
+	if len(crib) != 0 || cap(crib) != 4 {
+		t.Errorf("Expected an empty crib with room for four cards, got %v (cap %d)", crib, cap(crib))
+	}
+	if deck.Size() != 39 || deck.Contains(starter) {
+		t.Errorf("Expected the starter to be removed, leaving 39 cards, got %d", deck.Size())
+	}
+	for _, hand := range hands {
+		for _, card := range hand {
+			if card == starter {
+				t.Errorf("Starter %v was also dealt to a player", starter)
+			}
+		}
+	}
+
+	if _, _, _, err := NewDeckFromCards(NewDeck().Cards()[:12]).DealCribbage(); err == nil {
+		t.Error("Expected error with too few cards for a starter")
+	}
+}
@@ -0,0 +1,75 @@
+package deck
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// MarshalJSON encodes the suit as its name, such as "Hearts"
+func (s Suit) MarshalJSON() ([]byte, error) {
+	if !s.IsValid() {
+		return nil, errors.New("invalid suit")
+	}
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a suit from its name, Unicode symbol ("♥"), ASCII
+// letter ("h") or number. Names and letters are matched case-insensitively.
+func (s *Suit) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		if !Suit(n).IsValid() {
+			return errors.New("invalid suit")
+		}
+		*s = Suit(n)
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return errors.New("suit must be a string or number")
+	}
+	for suit := Spades; suit <= Clubs; suit++ {
+		if strings.EqualFold(name, suit.String()) || name == suit.Symbol() ||
+			strings.EqualFold(name, ASCIISymbols.Symbol(suit)) {
+			*s = suit
+			return nil
+		}
+	}
+	return errors.New("invalid suit")
+}
+
+// MarshalJSON encodes the rank as its name, such as "Ace"
+func (r Rank) MarshalJSON() ([]byte, error) {
+	if !r.IsValid() {
+		return nil, errors.New("invalid rank")
+	}
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON decodes a rank from its name, symbol ("A", "10" or "T") or
+// number, matched case-insensitively
+func (r *Rank) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		if !Rank(n).IsValid() {
+			return errors.New("invalid rank")
+		}
+		*r = Rank(n)
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return errors.New("rank must be a string or number")
+	}
+	for rank := Ace; rank <= King; rank++ {
+		if strings.EqualFold(name, rank.String()) || strings.EqualFold(name, rank.Symbol()) ||
+			strings.EqualFold(name, rank.compactSymbol()) {
+			*r = rank
+			return nil
+		}
+	}
+	return errors.New("invalid rank")
+}
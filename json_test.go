@@ -0,0 +1,86 @@
+package deck
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSuitJSON(t *testing.T) {
+	for suit := Spades; suit <= Clubs; suit++ {
+		data, err := json.Marshal(suit)
+		if err != nil {
+			t.Fatalf("Unexpected error marshaling %v: %v", suit, err)
+		}
+		if string(data) != `"`+suit.String()+`"` {
+			t.Errorf("Expected %v to marshal to its name, got %s", suit, data)
+		}
+
+		var decoded Suit
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != suit {
+			t.Errorf("Expected %v to round-trip, got %v (%v)", suit, decoded, err)
+		}
+	}
+
+	tests := map[string]Suit{`"hearts"`: Hearts, `"♦"`: Diamonds, `"C"`: Clubs, `0`: Spades}
+	for input, expected := range tests {
+		var suit Suit
+		if err := json.Unmarshal([]byte(input), &suit); err != nil || suit != expected {
+			t.Errorf("Expected %s to decode to %v, got %v (%v)", input, expected, suit, err)
+		}
+	}
+
+	for _, input := range []string{`"Stars"`, `4`, `-1`, `true`} {
+		var suit Suit
+		if err := json.Unmarshal([]byte(input), &suit); err == nil {
+			t.Errorf("Expected error decoding %s", input)
+		}
+	}
+	if _, err := json.Marshal(Suit(9)); err == nil {
+		t.Error("Expected error marshaling an invalid suit")
+	}
+}
+
+func TestRankJSON(t *testing.T) {
+	for rank := Ace; rank <= King; rank++ {
+		data, err := json.Marshal(rank)
+		if err != nil {
+			t.Fatalf("Unexpected error marshaling %v: %v", rank, err)
+		}
+		if string(data) != `"`+rank.String()+`"` {
+			t.Errorf("Expected %v to marshal to its name, got %s", rank, data)
+		}
+
+		var decoded Rank
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != rank {
+			t.Errorf("Expected %v to round-trip, got %v (%v)", rank, decoded, err)
+		}
+	}
+
+	tests := map[string]Rank{`"queen"`: Queen, `"A"`: Ace, `"10"`: Ten, `"t"`: Ten, `"7"`: Seven, `13`: King}
+	for input, expected := range tests {
+		var rank Rank
+		if err := json.Unmarshal([]byte(input), &rank); err != nil || rank != expected {
+			t.Errorf("Expected %s to decode to %v, got %v (%v)", input, expected, rank, err)
+		}
+	}
+
+	for _, input := range []string{`"Knight"`, `0`, `14`, `null`} {
+		var rank Rank
+		if err := json.Unmarshal([]byte(input), &rank); err == nil {
+			t.Errorf("Expected error decoding %s", input)
+		}
+	}
+	if _, err := json.Marshal(Rank(0)); err == nil {
+		t.Error("Expected error marshaling an invalid rank")
+	}
+}
+
+func TestCardJSON(t *testing.T) {
+	data, err := json.Marshal(NewCard(Hearts, Queen))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != `{"Suit":"Hearts","Rank":"Queen"}` {
+		t.Errorf("Unexpected card encoding: %s", data)
+	}
+}
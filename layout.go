@@ -0,0 +1,58 @@
+package deck
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DisplayWidth returns the width, in terminal columns, of the card as drawn
+// by LayoutRow: its short string plus a border column on each side. Tens are
+// one column wider than other ranks.
+func (c Card) DisplayWidth() int {
+	return utf8.RuneCountInString(c.ShortString()) + 2
+}
+
+// cardRows returns the three rows of a card drawn as a small box, e.g.
+//
+//	+--+
+//	|A♠|
+//	+--+
+func cardRows(c Card) [3][]rune {
+	edge := "+" + strings.Repeat("-", c.DisplayWidth()-2) + "+"
+	return [3][]rune{
+		[]rune(edge),
+		[]rune("|" + c.ShortString() + "|"),
+		[]rune(edge),
+	}
+}
+
+// LayoutRow renders cards as a fanned row for a terminal UI, returning three
+// newline-separated lines. An overlap of zero lays the cards side by side.
+// Otherwise each card except the last is overlapped by the one after it,
+// showing only its left border and corner index (rank and suit, including
+// both digits of a Ten). The box has just one column to the right of the
+// corner, so every overlap of 1 or more gives the same corner-only layout.
+// The last card is always drawn in full.
+func LayoutRow(cards []Card, overlap int) string {
+	if len(cards) == 0 {
+		return ""
+	}
+	if overlap < 0 {
+		overlap = 0
+	}
+
+	var lines [3]strings.Builder
+	for i, card := range cards {
+		rows := cardRows(card)
+		visible := card.DisplayWidth()
+		if i < len(cards)-1 {
+			corner := utf8.RuneCountInString(card.ShortString()) + 1
+			visible = max(visible-overlap, corner)
+		}
+		for j, row := range rows {
+			lines[j].WriteString(string(row[:visible]))
+		}
+	}
+
+	return lines[0].String() + "\n" + lines[1].String() + "\n" + lines[2].String()
+}
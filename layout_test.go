@@ -0,0 +1,38 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	if width := NewCard(Spades, Ace).DisplayWidth(); width != 4 {
+		t.Errorf("Expected Ace of Spades to be 4 columns wide, got %d", width)
+	}
+
+	if width := NewCard(Hearts, Ten).DisplayWidth(); width != 5 {
+		t.Errorf("Expected Ten of Hearts to be 5 columns wide, got %d", width)
+	}
+}
+
+func TestLayoutRow(t *testing.T) {
+	cards := []Card{NewCard(Spades, Ace), NewCard(Hearts, Ten), NewCard(Clubs, King)}
+
+	expected := "+--+---+--+\n|A♠|10♥|K♣|\n+--+---+--+"
+	if got := LayoutRow(cards, 10); got != expected {
+		t.Errorf("Expected fanned layout:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// The corner is all that can stay visible, so any positive overlap matches
+	if LayoutRow(cards, 1) != LayoutRow(cards, 3) {
+		t.Errorf("Expected overlap 1 and 3 to match:\n%s\n%s", LayoutRow(cards, 1), LayoutRow(cards, 3))
+	}
+
+	expected = "+--++---++--+\n|A♠||10♥||K♣|\n+--++---++--+"
+	if got := LayoutRow(cards, 0); got != expected {
+		t.Errorf("Expected side-by-side layout:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if LayoutRow(nil, 1) != "" {
+		t.Error("Expected empty layout for no cards")
+	}
+}
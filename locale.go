@@ -0,0 +1,57 @@
+package deck
+
+// Language selects the language used by the StringLocalized methods
+type Language int
+
+const (
+	English Language = iota
+	Spanish
+)
+
+// localeNames holds the translated names for one language. Ranks are indexed
+// by Rank, so index 0 is unused.
+type localeNames struct {
+	suits [4]string
+	ranks [14]string
+	of    string
+}
+
+var locales = map[Language]localeNames{
+	Spanish: {
+		suits: [4]string{"Picas", "Corazones", "Diamantes", "Tréboles"},
+		ranks: [14]string{"", "As", "Dos", "Tres", "Cuatro", "Cinco", "Seis", "Siete",
+			"Ocho", "Nueve", "Diez", "Jota", "Reina", "Rey"},
+		of: "de",
+	},
+}
+
+// StringLocalized returns the name of the suit in the given language. English
+// and unsupported languages return String.
+func (s Suit) StringLocalized(lang Language) string {
+	names, ok := locales[lang]
+	if !ok || !s.IsValid() {
+		return s.String()
+	}
+	return names.suits[s]
+}
+
+// StringLocalized returns the name of the rank in the given language. English
+// and unsupported languages return String.
+func (r Rank) StringLocalized(lang Language) string {
+	names, ok := locales[lang]
+	if !ok || !r.IsValid() {
+		return r.String()
+	}
+	return names.ranks[r]
+}
+
+// StringLocalized returns the name of the card in the given language, such as
+// "As de Corazones" in Spanish. English and unsupported languages return
+// String.
+func (c Card) StringLocalized(lang Language) string {
+	names, ok := locales[lang]
+	if !ok {
+		return c.String()
+	}
+	return c.Rank.StringLocalized(lang) + " " + names.of + " " + c.Suit.StringLocalized(lang)
+}
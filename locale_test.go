@@ -0,0 +1,30 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestStringLocalized(t *testing.T) {
+	tests := []struct {
+		card     Card
+		lang     Language
+		expected string
+	}{
+		{NewCard(Hearts, Ace), English, "Ace of Hearts"},
+		{NewCard(Hearts, Ace), Spanish, "As de Corazones"},
+		{NewCard(Clubs, King), Spanish, "Rey de Tréboles"},
+		{NewCard(Spades, Ten), Spanish, "Diez de Picas"},
+		{NewCard(Diamonds, Jack), Language(99), "Jack of Diamonds"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.card.StringLocalized(tt.lang); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+
+	// String stays English regardless
+	if got := NewCard(Hearts, Ace).String(); got != "Ace of Hearts" {
+		t.Errorf("Expected String to stay English, got %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+package deck
+
+import (
+	"errors"
+	"strings"
+)
+
+// Characters used by standard poker notation, indexed by Rank-1 and by Suit
+const (
+	notationRanks = "A23456789TJQK"
+	notationSuits = "shdc"
+)
+
+// EncodePokerNotation writes cards in the two-character notation used by hand
+// histories and equity tools, such as "AsKh" or "TdTc": an upper-case rank,
+// with T for Ten, followed by a lower-case suit. Invalid cards are written as
+// "??".
+func EncodePokerNotation(cards []Card) string {
+	var b strings.Builder
+	b.Grow(2 * len(cards))
+	for _, card := range cards {
+		if !card.IsValid() {
+			b.WriteString("??")
+			continue
+		}
+		b.WriteByte(notationRanks[card.Rank-1])
+		b.WriteByte(notationSuits[card.Suit])
+	}
+	return b.String()
+}
+
+// ParsePokerNotation parses cards written in poker notation, the inverse of
+// EncodePokerNotation. Ranks and suits are matched case-insensitively and
+// whitespace between cards is ignored, so "As Kh" and "asKH" are both
+// accepted.
+func ParsePokerNotation(s string) ([]Card, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if len(s)%2 != 0 {
+		return nil, errors.New("poker notation must have two characters per card")
+	}
+
+	cards := make([]Card, 0, len(s)/2)
+	for i := 0; i < len(s); i += 2 {
+		rank := strings.IndexByte(notationRanks, upper(s[i]))
+		suit := strings.IndexByte(notationSuits, lower(s[i+1]))
+		if rank < 0 || suit < 0 {
+			return nil, errors.New("invalid card in poker notation")
+		}
+		cards = append(cards, NewCard(Suit(suit), Rank(rank+1)))
+	}
+	return cards, nil
+}
+
+// upper returns the upper-case form of an ASCII letter
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// lower returns the lower-case form of an ASCII letter
+func lower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c - 'A' + 'a'
+	}
+	return c
+}
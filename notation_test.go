@@ -0,0 +1,52 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestPokerNotation(t *testing.T) {
+	royal := []Card{
+		NewCard(Spades, Ace),
+		NewCard(Spades, King),
+		NewCard(Spades, Queen),
+		NewCard(Spades, Jack),
+		NewCard(Spades, Ten),
+	}
+	if got := EncodePokerNotation(royal); got != "AsKsQsJsTs" {
+		t.Errorf("Expected AsKsQsJsTs, got %s", got)
+	}
+
+	cards, err := ParsePokerNotation("AsKsQsJsTs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if EvaluatePokerHand(cards) != RoyalFlush {
+		t.Errorf("Expected a royal flush, got %v", EvaluatePokerHand(cards))
+	}
+
+	// Every card round-trips
+	all := NewDeck().Cards()
+	parsed, err := ParsePokerNotation(EncodePokerNotation(all))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := range all {
+		if parsed[i] != all[i] {
+			t.Errorf("Expected %v at position %d, got %v", all[i], i, parsed[i])
+		}
+	}
+
+	cards, err = ParsePokerNotation("2c 9D th")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cards) != 3 || cards[0] != NewCard(Clubs, Two) || cards[1] != NewCard(Diamonds, Nine) || cards[2] != NewCard(Hearts, Ten) {
+		t.Errorf("Unexpected cards parsed: %v", cards)
+	}
+
+	for _, invalid := range []string{"A", "Ax", "1s", "10s", "??"} {
+		if _, err := ParsePokerNotation(invalid); err == nil {
+			t.Errorf("Expected error parsing %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,535 @@
+package deck
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// HandRank represents the category of a poker hand, from weakest to strongest
+type HandRank int
+
+const (
+	HighCard HandRank = iota
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+	RoyalFlush
+	FiveOfAKind
+)
+
+// maxJokers is the most wild jokers EvaluatePokerHandWithJokers will accept
+const maxJokers = 2
+
+// maxDealAttempts caps the number of reshuffles DealHandAtLeast will try
+const maxDealAttempts = 10000
+
+// String returns the string representation of a hand rank
+func (h HandRank) String() string {
+	switch h {
+	case HighCard:
+		return "High Card"
+	case OnePair:
+		return "One Pair"
+	case TwoPair:
+		return "Two Pair"
+	case ThreeOfAKind:
+		return "Three of a Kind"
+	case Straight:
+		return "Straight"
+	case Flush:
+		return "Flush"
+	case FullHouse:
+		return "Full House"
+	case FourOfAKind:
+		return "Four of a Kind"
+	case StraightFlush:
+		return "Straight Flush"
+	case RoyalFlush:
+		return "Royal Flush"
+	case FiveOfAKind:
+		return "Five of a Kind"
+	default:
+		return "Unknown"
+	}
+}
+
+// EvaluatePokerHand returns the best poker hand rank that can be made from
+// the given cards. Any number of cards may be passed; straights and flushes
+// need at least five, so with seven cards (as in Texas Hold'em) the result is
+// the best five-card hand. Five of a kind is only possible when the cards come
+// from more than one deck. Invalid cards are ignored.
+func EvaluatePokerHand(cards []Card) HandRank {
+	var suitCounts [Clubs + 1]int
+	var suitMasks [Clubs + 1]uint16
+	var mask uint16
+	for _, card := range cards {
+		if !card.IsValid() {
+			continue
+		}
+		suitCounts[card.Suit]++
+		suitMasks[card.Suit] |= rankBit(card.Rank)
+		mask |= rankBit(card.Rank)
+	}
+
+	hist := RankHistogram(cards)
+	pairs, trips, quads := hist[1], hist[2], hist[3]
+	if hist[4] > 0 {
+		return FiveOfAKind
+	}
+
+	flush := false
+	for suit, count := range suitCounts {
+		if count < 5 {
+			continue
+		}
+		flush = true
+		if high := straightHigh(suitMasks[suit]); high == 14 {
+			return RoyalFlush
+		} else if high > 0 {
+			return StraightFlush
+		}
+	}
+
+	switch {
+	case quads > 0:
+		return FourOfAKind
+	case trips > 1 || (trips == 1 && pairs > 0):
+		return FullHouse
+	case flush:
+		return Flush
+	case straightHigh(mask) > 0:
+		return Straight
+	case trips == 1:
+		return ThreeOfAKind
+	case pairs > 1:
+		return TwoPair
+	case pairs == 1:
+		return OnePair
+	default:
+		return HighCard
+	}
+}
+
+// RankHistogram counts how many ranks appear once, twice, three, four and
+// five or more times in the cards, in elements 0 to 4. The result is the
+// classic signature for classifying hands: a full house is {0, 1, 1, 0, 0},
+// two pair with a kicker {1, 2, 0, 0, 0} and four of a kind {1, 0, 0, 1, 0}.
+// Invalid cards are ignored.
+func RankHistogram(cards []Card) [5]int {
+	var counts [King + 1]int
+	for _, card := range cards {
+		if card.IsValid() {
+			counts[card.Rank]++
+		}
+	}
+
+	var hist [5]int
+	for _, count := range counts {
+		switch {
+		case count >= 5:
+			hist[4]++
+		case count > 0:
+			hist[count-1]++
+		}
+	}
+	return hist
+}
+
+// EvaluatePokerHandWithJokers returns the best poker hand rank that can be
+// made from the given cards plus up to two wild jokers. Each joker is tried
+// as every standard card, including duplicates of cards already held, so
+// four Aces and a joker make five of a kind.
+func EvaluatePokerHandWithJokers(cards []Card, jokers int) (HandRank, error) {
+	if jokers < 0 {
+		return HighCard, errors.New("number of jokers cannot be negative")
+	}
+	if jokers > maxJokers {
+		return HighCard, errors.New("too many jokers")
+	}
+
+	hand := make([]Card, len(cards), len(cards)+jokers)
+	copy(hand, cards)
+	return bestWithJokers(hand, jokers), nil
+}
+
+// bestWithJokers substitutes each joker in turn with every standard card and
+// returns the best rank found
+func bestWithJokers(hand []Card, jokers int) HandRank {
+	if jokers == 0 {
+		return EvaluatePokerHand(hand)
+	}
+
+	hand = append(hand, Card{})
+	last := len(hand) - 1
+	best := HighCard
+	for suit := Spades; suit <= Clubs; suit++ {
+		for rank := Ace; rank <= King; rank++ {
+			hand[last] = NewCard(suit, rank)
+			if got := bestWithJokers(hand, jokers-1); got > best {
+				best = got
+			}
+		}
+	}
+	return best
+}
+
+// rankBit returns the bit for a rank in a rank mask, where bit n stands for
+// value n. Aces set both bit 1 and bit 14 so they play low and high.
+func rankBit(r Rank) uint16 {
+	if r == Ace {
+		return 1<<1 | 1<<14
+	}
+	return 1 << r
+}
+
+// straightHigh returns the value of the highest card of the best straight in
+// a rank mask, with Ace counting as 14 (or 1 in the Ace-to-Five wheel), or 0
+// if the mask contains no straight
+func straightHigh(mask uint16) int {
+	for high := 14; high >= 5; high-- {
+		run := uint16(0x1f) << (high - 4)
+		if mask&run == run {
+			return high
+		}
+	}
+	return 0
+}
+
+// IsFlushDraw returns true if the cards hold exactly four of one suit, so one
+// more card of that suit makes a flush. A made flush is not a draw and
+// returns false. Invalid cards are ignored.
+func IsFlushDraw(cards []Card) bool {
+	var suitCounts [Clubs + 1]int
+	for _, card := range cards {
+		if card.IsValid() {
+			suitCounts[card.Suit]++
+		}
+	}
+
+	draw := false
+	for _, count := range suitCounts {
+		if count >= 5 {
+			return false
+		}
+		if count == 4 {
+			draw = true
+		}
+	}
+	return draw
+}
+
+// IsOpenEndedStraightDraw returns true if the cards hold four consecutive
+// ranks that a card at either end would complete to a straight, such as
+// 5-6-7-8 waiting on a Four or a Nine. One-ended draws, which includes
+// A-2-3-4 and J-Q-K-A as well as inside (gutshot) draws like 5-6-8-9, return
+// false, as does a made straight. Invalid cards are ignored.
+func IsOpenEndedStraightDraw(cards []Card) bool {
+	var mask uint16
+	for _, card := range cards {
+		if card.IsValid() {
+			mask |= rankBit(card.Rank)
+		}
+	}
+	if straightHigh(mask) > 0 {
+		return false
+	}
+
+	// The run's low card must be at least Two and its high card at most King
+	// so there is a rank free on both sides
+	for low := 2; low <= 10; low++ {
+		run := uint16(0xf) << low
+		if mask&run == run {
+			return true
+		}
+	}
+	return false
+}
+
+// DealHandAtLeast deals a hand of size cards whose poker rank is at least the
+// given rank. The deck is reshuffled until the top cards qualify, giving up
+// with an error after a fixed number of attempts.
+func (d *Deck) DealHandAtLeast(rank HandRank, size int) ([]Card, error) {
+	if size < 0 {
+		return nil, errors.New("cannot deal negative number of cards")
+	}
+	if size > d.Size() {
+		return nil, errors.New("not enough cards in deck")
+	}
+
+	for attempt := 0; attempt < maxDealAttempts; attempt++ {
+		d.Shuffle()
+		if EvaluatePokerHand(d.cards()[:size]) >= rank {
+			return d.DealN(size)
+		}
+	}
+	return nil, errors.New("could not deal requested hand within attempt limit")
+}
+
+// DealCategorized deals cardsEach cards to each player, one at a time, and
+// returns the hands with their poker ranks, sorted from best to worst. Hands
+// of equal rank stay in player order.
+func (d *Deck) DealCategorized(players, cardsEach int) ([]HandRank, [][]Card, error) {
+	hands, err := d.dealRoundRobin(players, cardsEach)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ranks := make([]HandRank, len(hands))
+	for i, hand := range hands {
+		ranks[i] = EvaluatePokerHand(hand)
+	}
+
+	order := make([]int, len(hands))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return ranks[order[i]] > ranks[order[j]]
+	})
+
+	sortedRanks := make([]HandRank, len(hands))
+	sortedHands := make([][]Card, len(hands))
+	for i, player := range order {
+		sortedRanks[i] = ranks[player]
+		sortedHands[i] = hands[player]
+	}
+	return sortedRanks, sortedHands, nil
+}
+
+// handScore orders poker hands: the hand rank followed by up to five
+// tie-breaking card values, with Ace counted as 14. Scores compare
+// lexicographically.
+type handScore [6]int
+
+// CompareHands compares the best poker hands that can be made from a and b,
+// returning 1 if a wins, -1 if b wins and 0 for a tie. Hands of the same rank
+// are separated by the cards that make them and then by kickers, so a pair
+// of Kings beats a pair of Queens and A-A-K-x-x beats A-A-Q-x-x. Suits never
+// break ties. Hands of more than five cards play their best five; invalid
+// cards are ignored.
+func CompareHands(a, b []Card) int {
+	sa, sb := scoreHand(a), scoreHand(b)
+	for i := range sa {
+		switch {
+		case sa[i] > sb[i]:
+			return 1
+		case sa[i] < sb[i]:
+			return -1
+		}
+	}
+	return 0
+}
+
+// Winners returns the indices of the hands that share the best poker hand,
+// as ranked by CompareHands, and the rank of that hand. More than one index
+// means a split pot. It returns nil and HighCard if there are no hands.
+func Winners(hands [][]Card) ([]int, HandRank) {
+	var winners []int
+	var best handScore
+	for i, hand := range hands {
+		score := scoreHand(hand)
+		switch {
+		case winners == nil || score.beats(best):
+			winners = []int{i}
+			best = score
+		case score == best:
+			winners = append(winners, i)
+		}
+	}
+	return winners, HandRank(best[0])
+}
+
+// beats reports whether s is strictly better than other
+func (s handScore) beats(other handScore) bool {
+	for i := range s {
+		if s[i] != other[i] {
+			return s[i] > other[i]
+		}
+	}
+	return false
+}
+
+// scoreHand returns the score of the best five-card hand in cards
+func scoreHand(cards []Card) handScore {
+	valid := make([]Card, 0, len(cards))
+	for _, card := range cards {
+		if card.IsValid() {
+			valid = append(valid, card)
+		}
+	}
+	if len(valid) <= 5 {
+		return scoreFive(valid)
+	}
+
+	var best handScore
+	forEachCombination(valid, 5, func(combo []Card) bool {
+		if score := scoreFive(combo); score.beats(best) {
+			best = score
+		}
+		return true
+	})
+	return best
+}
+
+// scoreFive scores a hand of at most five valid cards
+func scoreFive(cards []Card) handScore {
+	rank := EvaluatePokerHand(cards)
+	score := handScore{int(rank)}
+
+	switch rank {
+	case Straight, StraightFlush, RoyalFlush:
+		var mask uint16
+		for _, card := range cards {
+			mask |= rankBit(card.Rank)
+		}
+		score[1] = straightHigh(mask)
+		return score
+	}
+
+	// Order values by how often they appear, then by value, so the cards
+	// that make the hand come before the kickers
+	var counts [15]int
+	for _, card := range cards {
+		counts[card.Rank.aceHighValue()]++
+	}
+	values := make([]int, 0, 5)
+	for v := 14; v >= 2; v-- {
+		if counts[v] > 0 {
+			values = append(values, v)
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return counts[values[i]] > counts[values[j]]
+	})
+	copy(score[1:], values)
+	return score
+}
+
+const (
+	// exactProbabilityLimit is the largest number of draws HandProbability
+	// will enumerate exactly; C(52, 5) fits within it
+	exactProbabilityLimit = 3000000
+
+	// probabilityTrials is the number of Monte Carlo trials HandProbability
+	// runs when exact enumeration would be too expensive
+	probabilityTrials = 100000
+)
+
+// HandProbability returns the probability of making at least the given hand
+// rank when drawing draw cards from the current deck, leaving out any cards
+// marked dead with SetDead. When the number of possible draws is at most
+// 3,000,000 (which covers any five-card draw from a single deck) every
+// combination is enumerated and the result is exact; otherwise it is
+// estimated from 100,000 random draws. It returns 0 if draw is not between 1
+// and the number of live cards.
+func (d *Deck) HandProbability(rank HandRank, draw int) float64 {
+	cards := d.live()
+	if draw <= 0 || draw > len(cards) {
+		return 0
+	}
+
+	if total := binomial(len(cards), draw, exactProbabilityLimit+1); total <= exactProbabilityLimit {
+		hits := 0
+		forEachCombination(cards, draw, func(hand []Card) bool {
+			if EvaluatePokerHand(hand) >= rank {
+				hits++
+			}
+			return true
+		})
+		return float64(hits) / float64(total)
+	}
+
+	hits := 0
+	for trial := 0; trial < probabilityTrials; trial++ {
+		// Partial Fisher-Yates: the first draw positions become a random sample
+		for i := 0; i < draw; i++ {
+			j := i + rand.Intn(len(cards)-i)
+			cards[i], cards[j] = cards[j], cards[i]
+		}
+		if EvaluatePokerHand(cards[:draw]) >= rank {
+			hits++
+		}
+	}
+	return float64(hits) / float64(probabilityTrials)
+}
+
+// CountOuts returns how many cards in the deck would give at least the given
+// hand rank if added to hand, such as the nine outs to a flush draw. Cards
+// marked dead with SetDead are not counted. If hand already makes the rank
+// there is nothing to draw to and the result is 0. Each copy of a card in a
+// multi-deck shoe counts as a separate out; the cards in hand are expected to
+// be out of the deck already.
+func (d *Deck) CountOuts(hand []Card, rank HandRank) int {
+	if EvaluatePokerHand(hand) >= rank {
+		return 0
+	}
+
+	drawn := make([]Card, len(hand), len(hand)+1)
+	copy(drawn, hand)
+	drawn = append(drawn, Card{})
+	outs := 0
+	for _, card := range d.live() {
+		drawn[len(hand)] = card
+		if EvaluatePokerHand(drawn) >= rank {
+			outs++
+		}
+	}
+	return outs
+}
+
+// RoyalFlushHand returns a royal flush (Ten through Ace) in the given suit
+func RoyalFlushHand(suit Suit) []Card {
+	return StraightFlushHand(suit, Ace)
+}
+
+// StraightFlushHand returns a five-card straight flush in the given suit
+// with high as its top card, lowest card first. A high card of Five gives the
+// Ace-to-Five wheel and Ace gives a royal flush. It returns nil if high is
+// below Five or the suit is invalid.
+func StraightFlushHand(suit Suit, high Rank) []Card {
+	if !suit.IsValid() || !high.IsValid() || (high != Ace && high < Five) {
+		return nil
+	}
+
+	cards := make([]Card, 0, 5)
+	top := high.aceHighValue()
+	for value := top - 4; value <= top; value++ {
+		rank := Rank(value)
+		if value == 1 || value == 14 {
+			rank = Ace
+		}
+		cards = append(cards, NewCard(suit, rank))
+	}
+	return cards
+}
+
+// FourOfAKindHand returns all four cards of rank plus a kicker of the given
+// rank in Spades. It returns nil if the ranks are equal or invalid.
+func FourOfAKindHand(rank, kicker Rank) []Card {
+	if !rank.IsValid() || !kicker.IsValid() || rank == kicker {
+		return nil
+	}
+	return []Card{
+		NewCard(Spades, rank), NewCard(Hearts, rank), NewCard(Diamonds, rank), NewCard(Clubs, rank),
+		NewCard(Spades, kicker),
+	}
+}
+
+// FullHouseHand returns three cards of trips (Spades, Hearts, Diamonds) and
+// two of pair (Spades, Hearts). It returns nil if the ranks are equal or
+// invalid.
+func FullHouseHand(trips, pair Rank) []Card {
+	if !trips.IsValid() || !pair.IsValid() || trips == pair {
+		return nil
+	}
+	return []Card{
+		NewCard(Spades, trips), NewCard(Hearts, trips), NewCard(Diamonds, trips),
+		NewCard(Spades, pair), NewCard(Hearts, pair),
+	}
+}
@@ -0,0 +1,410 @@
+package deck
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluatePokerHand(t *testing.T) {
+	tests := []struct {
+		cards    []Card
+		expected HandRank
+	}{
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Five), NewCard(Clubs, Nine), NewCard(Diamonds, Jack), NewCard(Spades, King)}, HighCard},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Two), NewCard(Clubs, Nine), NewCard(Diamonds, Jack), NewCard(Spades, King)}, OnePair},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Two), NewCard(Clubs, Nine), NewCard(Diamonds, Nine), NewCard(Spades, King)}, TwoPair},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Two), NewCard(Clubs, Two), NewCard(Diamonds, Jack), NewCard(Spades, King)}, ThreeOfAKind},
+		{[]Card{NewCard(Spades, Ace), NewCard(Hearts, Two), NewCard(Clubs, Three), NewCard(Diamonds, Four), NewCard(Spades, Five)}, Straight},
+		{[]Card{NewCard(Spades, Ten), NewCard(Hearts, Jack), NewCard(Clubs, Queen), NewCard(Diamonds, King), NewCard(Spades, Ace)}, Straight},
+		{[]Card{NewCard(Hearts, Two), NewCard(Hearts, Five), NewCard(Hearts, Nine), NewCard(Hearts, Jack), NewCard(Hearts, King)}, Flush},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Two), NewCard(Clubs, Two), NewCard(Diamonds, King), NewCard(Spades, King)}, FullHouse},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Two), NewCard(Clubs, Two), NewCard(Diamonds, Two), NewCard(Spades, King)}, FourOfAKind},
+		{[]Card{NewCard(Clubs, Five), NewCard(Clubs, Six), NewCard(Clubs, Seven), NewCard(Clubs, Eight), NewCard(Clubs, Nine)}, StraightFlush},
+		{[]Card{NewCard(Clubs, Ten), NewCard(Clubs, Jack), NewCard(Clubs, Queen), NewCard(Clubs, King), NewCard(Clubs, Ace)}, RoyalFlush},
+	}
+
+	for _, tt := range tests {
+		if got := EvaluatePokerHand(tt.cards); got != tt.expected {
+			t.Errorf("Expected %v for %v, got %v", tt.expected, tt.cards, got)
+		}
+	}
+}
+
+func TestEvaluatePokerHandSevenCards(t *testing.T) {
+	// Queen-high straight with a pair on the board
+	cards := []Card{
+		NewCard(Spades, Eight), NewCard(Hearts, Nine), NewCard(Clubs, Ten),
+		NewCard(Diamonds, Jack), NewCard(Spades, Queen), NewCard(Hearts, Queen),
+		NewCard(Clubs, Two),
+	}
+	if got := EvaluatePokerHand(cards); got != Straight {
+		t.Errorf("Expected Straight, got %v", got)
+	}
+
+	// No wraparound straights
+	cards = []Card{
+		NewCard(Spades, Queen), NewCard(Hearts, King), NewCard(Clubs, Ace),
+		NewCard(Diamonds, Two), NewCard(Spades, Three),
+	}
+	if got := EvaluatePokerHand(cards); got != HighCard {
+		t.Errorf("Expected High Card for wraparound, got %v", got)
+	}
+}
+
+func TestEvaluatePokerHandFiveOfAKind(t *testing.T) {
+	// Only possible with more than one deck
+	cards := []Card{
+		NewCard(Spades, Nine), NewCard(Hearts, Nine), NewCard(Clubs, Nine),
+		NewCard(Diamonds, Nine), NewCard(Spades, Nine),
+	}
+	if got := EvaluatePokerHand(cards); got != FiveOfAKind {
+		t.Errorf("Expected Five of a Kind, got %v", got)
+	}
+}
+
+func TestRankHistogram(t *testing.T) {
+	tests := []struct {
+		cards    []Card
+		expected [5]int
+	}{
+		{FullHouseHand(Seven, Two), [5]int{0, 1, 1, 0, 0}},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Two), NewCard(Clubs, Nine), NewCard(Diamonds, Nine), NewCard(Spades, King)}, [5]int{1, 2, 0, 0, 0}},
+		{FourOfAKindHand(Jack, Three), [5]int{1, 0, 0, 1, 0}},
+		{RoyalFlushHand(Hearts), [5]int{5, 0, 0, 0, 0}},
+		{[]Card{NewCard(Spades, Two), {}, NewCard(Hearts, Two)}, [5]int{0, 1, 0, 0, 0}},
+		{nil, [5]int{}},
+	}
+
+	for _, tt := range tests {
+		if got := RankHistogram(tt.cards); got != tt.expected {
+			t.Errorf("Expected %v for %v, got %v", tt.expected, tt.cards, got)
+		}
+	}
+}
+
+func TestIsFlushDraw(t *testing.T) {
+	tests := []struct {
+		hand     string
+		expected bool
+	}{
+		{"Ah Kh 7h 2h 9c", true},
+		{"Ah Kh 7h 2c 9c", false},
+		{"Ah Kh 7h 2h 9h", false}, // made flush
+		{"Ah Kh 7h 2h", true},
+		{"Ah Kh 7h 2h 9c Td Tc", true},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		cards, err := ParsePokerNotation(tt.hand)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %v", tt.hand, err)
+		}
+		if got := IsFlushDraw(cards); got != tt.expected {
+			t.Errorf("Expected %v for %q, got %v", tt.expected, tt.hand, got)
+		}
+	}
+}
+
+func TestIsOpenEndedStraightDraw(t *testing.T) {
+	tests := []struct {
+		hand     string
+		expected bool
+	}{
+		{"5s 6h 7d 8c Kd", true},
+		{"2s 3h 4d 5c Kd", true},  // Ace or Six
+		{"9s Th Jd Qc 3d", true},  // Eight or King
+		{"5s 6h 8d 9c Kd", false}, // gutshot
+		{"As 2h 3d 4c 9d", false}, // only a Five completes it
+		{"Js Qh Kd Ac 4d", false}, // only a Ten completes it
+		{"5s 6h 7d 8c 9d", false}, // made straight
+		{"5s 6h 7d 9c Jd", false}, // no four in a row
+		{"5s 6h 7d 8c 8d Ks Kh", true},
+	}
+
+	for _, tt := range tests {
+		cards, err := ParsePokerNotation(tt.hand)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %v", tt.hand, err)
+		}
+		if got := IsOpenEndedStraightDraw(cards); got != tt.expected {
+			t.Errorf("Expected %v for %q, got %v", tt.expected, tt.hand, got)
+		}
+	}
+}
+
+func TestEvaluatePokerHandWithJokers(t *testing.T) {
+	tests := []struct {
+		cards    []Card
+		jokers   int
+		expected HandRank
+	}{
+		// One joker fills the gap in a straight flush
+		{[]Card{NewCard(Hearts, Five), NewCard(Hearts, Six), NewCard(Hearts, Seven), NewCard(Hearts, Nine)}, 1, StraightFlush},
+		{[]Card{NewCard(Spades, Ten), NewCard(Spades, Jack), NewCard(Spades, Queen), NewCard(Spades, King)}, 1, RoyalFlush},
+		{[]Card{NewCard(Spades, Ace), NewCard(Hearts, Ace), NewCard(Clubs, Ace), NewCard(Diamonds, Ace)}, 1, FiveOfAKind},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Seven), NewCard(Clubs, King)}, 2, ThreeOfAKind},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Two), NewCard(Clubs, King)}, 2, FourOfAKind},
+		{[]Card{NewCard(Spades, Two), NewCard(Hearts, Seven), NewCard(Clubs, King), NewCard(Clubs, Four), NewCard(Diamonds, Nine)}, 0, HighCard},
+	}
+
+	for _, tt := range tests {
+		got, err := EvaluatePokerHandWithJokers(tt.cards, tt.jokers)
+		if err != nil {
+			t.Errorf("Unexpected error for %v: %v", tt.cards, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Expected %v for %v with %d jokers, got %v", tt.expected, tt.cards, tt.jokers, got)
+		}
+	}
+
+	if _, err := EvaluatePokerHandWithJokers(nil, 3); err == nil {
+		t.Error("Expected error with more than two jokers")
+	}
+	if _, err := EvaluatePokerHandWithJokers(nil, -1); err == nil {
+		t.Error("Expected error with negative jokers")
+	}
+}
+
+func TestDealHandAtLeast(t *testing.T) {
+	deck := NewDeck()
+
+	hand, err := deck.DealHandAtLeast(OnePair, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error dealing hand: %v", err)
+	}
+
+	if len(hand) != 5 {
+		t.Errorf("Expected 5 cards, got %d", len(hand))
+	}
+
+	if EvaluatePokerHand(hand) < OnePair {
+		t.Errorf("Expected at least a pair, got %v", EvaluatePokerHand(hand))
+	}
+
+	if deck.Size() != 47 {
+		t.Errorf("Expected deck size to be 47 after dealing, got %d", deck.Size())
+	}
+
+	// Four of a kind is impossible from five distinct ranks
+	small := NewDeckFromCards([]Card{
+		NewCard(Spades, Two), NewCard(Hearts, Five), NewCard(Clubs, Nine),
+		NewCard(Diamonds, Jack), NewCard(Spades, King),
+	})
+	if _, err := small.DealHandAtLeast(FourOfAKind, 5); err == nil {
+		t.Error("Expected error when threshold cannot be met")
+	}
+
+	if _, err := small.DealHandAtLeast(OnePair, 6); err == nil {
+		t.Error("Expected error when dealing more cards than available")
+	}
+}
+
+func TestCompareHands(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"KsKh2c5d9s", "QsQhAcJd9h", 1},
+		{"AsAdKc5h3s", "AhAcQd5s3c", 1},
+		{"AsAdKc5h3s", "AhAcKd5s3c", 0},
+		{"5s4h3c2dAs", "6h5c4d3s2c", -1},
+		{"7s7h7c2d2s", "6h6c6dAsAc", 1},
+		{"AhJh9h4h2h", "AsJs9s4s3s", -1},
+		{"2s3h", "2d4c", -1},
+		// Seven cards play their best five
+		{"AsKs9h9c4d3s2c", "AhQs9s9d4c3h2h", 1},
+	}
+
+	for _, tt := range tests {
+		a, _ := ParsePokerNotation(tt.a)
+		b, _ := ParsePokerNotation(tt.b)
+		if got := CompareHands(a, b); got != tt.expected {
+			t.Errorf("Expected CompareHands(%s, %s) = %d, got %d", tt.a, tt.b, tt.expected, got)
+		}
+		if got := CompareHands(b, a); got != -tt.expected {
+			t.Errorf("Expected CompareHands(%s, %s) = %d, got %d", tt.b, tt.a, -tt.expected, got)
+		}
+	}
+}
+
+func TestWinners(t *testing.T) {
+	board, _ := ParsePokerNotation("AsKsQsJsTs")
+	holes := []string{"2h3h", "4c5c", "6d7d"}
+
+	// The board plays for everyone: a three-way split
+	var hands [][]Card
+	for _, hole := range holes {
+		cards, _ := ParsePokerNotation(hole)
+		hands = append(hands, append(cards, board...))
+	}
+	winners, rank := Winners(hands)
+	if len(winners) != 3 || rank != RoyalFlush {
+		t.Errorf("Expected a three-way royal flush split, got %v with %v", winners, rank)
+	}
+
+	hands = nil
+	for _, hand := range []string{"2h2d5c9sKd", "AhAc3d7s8c", "AsAd3c7h6c"} {
+		cards, _ := ParsePokerNotation(hand)
+		hands = append(hands, cards)
+	}
+	winners, rank = Winners(hands)
+	if len(winners) != 1 || winners[0] != 1 || rank != OnePair {
+		t.Errorf("Expected player 1 to win with One Pair, got %v with %v", winners, rank)
+	}
+
+	if winners, _ := Winners(nil); winners != nil {
+		t.Errorf("Expected no winners without hands, got %v", winners)
+	}
+}
+
+func TestDealCategorized(t *testing.T) {
+	// Player 0 gets a pair of Twos, player 1 high card, player 2 a pair of Kings
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Two), NewCard(Spades, Four), NewCard(Spades, King),
+		NewCard(Hearts, Two), NewCard(Hearts, Five), NewCard(Hearts, King),
+	})
+
+	ranks, hands, err := deck.DealCategorized(3, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []HandRank{OnePair, OnePair, HighCard}
+	for i := range expected {
+		if ranks[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, ranks[i])
+		}
+	}
+
+	// Equal ranks keep player order
+	if hands[0][0].Rank != Two || hands[1][0].Rank != King || hands[2][0].Rank != Four {
+		t.Errorf("Unexpected hand order: %v", hands)
+	}
+
+	if _, _, err := deck.DealCategorized(3, 1); err == nil {
+		t.Error("Expected error dealing from an empty deck")
+	}
+}
+
+func TestHandProbability(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Ace), NewCard(Hearts, Ace), NewCard(Clubs, Ace), NewCard(Diamonds, Ace),
+		NewCard(Spades, King), NewCard(Hearts, King),
+	})
+
+	// Every five-card draw is at least a full house; two of six are quads
+	if p := deck.HandProbability(FullHouse, 5); p != 1 {
+		t.Errorf("Expected probability 1 of a full house, got %v", p)
+	}
+	if p := deck.HandProbability(FourOfAKind, 5); math.Abs(p-2.0/6.0) > 1e-9 {
+		t.Errorf("Expected probability 1/3 of four of a kind, got %v", p)
+	}
+
+	if p := deck.HandProbability(OnePair, 7); p != 0 {
+		t.Errorf("Expected probability 0 when drawing more cards than available, got %v", p)
+	}
+}
+
+func TestHandProbabilityFullDeck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive enumeration in short mode")
+	}
+
+	// 1,302,540 high-card hands out of 2,598,960
+	p := NewDeck().HandProbability(OnePair, 5)
+	onePairOrBetter := 1.0 - 1302540.0/2598960.0
+	if math.Abs(p-onePairOrBetter) > 1e-9 {
+		t.Errorf("Expected probability %v of at least a pair, got %v", onePairOrBetter, p)
+	}
+}
+
+func TestHandProbabilityDeadCards(t *testing.T) {
+	// Four Aces among six cards: one draw of four in C(6, 4) makes quads,
+	// unless an Ace is dead
+	deck := NewDeckFromCards(append(FourOfAKindHand(Ace, King), NewCard(Hearts, Queen)))
+	if p := deck.HandProbability(FourOfAKind, 4); math.Abs(p-1.0/15) > 1e-9 {
+		t.Errorf("Expected probability 1/15, got %v", p)
+	}
+
+	deck.SetDead([]Card{NewCard(Hearts, Ace)})
+	if p := deck.HandProbability(FourOfAKind, 4); p != 0 {
+		t.Errorf("Expected probability 0 with an Ace dead, got %v", p)
+	}
+	if deck.Size() != 6 || len(deck.Dead()) != 1 {
+		t.Errorf("Expected dead cards to stay in the deck, got %d cards and %v dead", deck.Size(), deck.Dead())
+	}
+
+	deck.Reset()
+	if len(deck.Dead()) != 0 {
+		t.Error("Expected Reset to clear the dead cards")
+	}
+}
+
+func TestCountOuts(t *testing.T) {
+	hand, _ := ParsePokerNotation("AsKs 7s 2s 9c")
+	deck := NewDeckExcluding(hand...)
+	if outs := deck.CountOuts(hand, Flush); outs != 9 {
+		t.Errorf("Expected 9 outs to the flush, got %d", outs)
+	}
+
+	// Spades shown by another player can't complete the flush
+	deck.SetDead([]Card{NewCard(Spades, Three), NewCard(Spades, Queen), NewCard(Hearts, Four)})
+	if outs := deck.CountOuts(hand, Flush); outs != 7 {
+		t.Errorf("Expected 7 outs with two Spades dead, got %d", outs)
+	}
+
+	// An open-ended straight draw has eight outs
+	hand, _ = ParsePokerNotation("5s 6h 7d 8c Kd")
+	if outs := NewDeckExcluding(hand...).CountOuts(hand, Straight); outs != 8 {
+		t.Errorf("Expected 8 outs to the straight, got %d", outs)
+	}
+
+	if outs := NewDeckExcluding(hand...).CountOuts(hand, HighCard); outs != 0 {
+		t.Errorf("Expected no outs to a rank already made, got %d", outs)
+	}
+}
+
+func TestHandPresets(t *testing.T) {
+	royal := RoyalFlushHand(Hearts)
+	if len(royal) != 5 || EvaluatePokerHand(royal) != RoyalFlush {
+		t.Errorf("Expected a royal flush, got %v", royal)
+	}
+	for _, card := range royal {
+		if card.Suit != Hearts {
+			t.Errorf("Expected all Hearts, got %v", card)
+		}
+	}
+
+	wheel := StraightFlushHand(Clubs, Five)
+	if len(wheel) != 5 || wheel[0] != NewCard(Clubs, Ace) || EvaluatePokerHand(wheel) != StraightFlush {
+		t.Errorf("Expected Ace-to-Five straight flush, got %v", wheel)
+	}
+
+	if hand := StraightFlushHand(Spades, Nine); EvaluatePokerHand(hand) != StraightFlush || hand[4].Rank != Nine {
+		t.Errorf("Expected Nine-high straight flush, got %v", hand)
+	}
+
+	if StraightFlushHand(Spades, Four) != nil {
+		t.Error("Expected nil for a straight flush below Five high")
+	}
+
+	if hand := FourOfAKindHand(Seven, Two); EvaluatePokerHand(hand) != FourOfAKind {
+		t.Errorf("Expected four of a kind, got %v", hand)
+	}
+
+	if hand := FullHouseHand(Queen, Three); EvaluatePokerHand(hand) != FullHouse {
+		t.Errorf("Expected full house, got %v", hand)
+	}
+
+	if FullHouseHand(Queen, Queen) != nil || FourOfAKindHand(Two, Two) != nil {
+		t.Error("Expected nil when ranks are equal")
+	}
+
+	// Each call returns a fresh slice
+	a, b := RoyalFlushHand(Spades), RoyalFlushHand(Spades)
+	a[0] = NewCard(Hearts, Two)
+	if b[0] == a[0] {
+		t.Error("Presets should return independent slices")
+	}
+}
@@ -0,0 +1,28 @@
+package deck
+
+import "sync"
+
+var deckPool = sync.Pool{
+	New: func() any {
+		return &Deck{buf: make([]Card, 0, 52)}
+	},
+}
+
+// AcquireDeck returns a standard 52-card deck in new-deck order, reusing the
+// storage of a previously released deck when one is available. It is intended
+// for high-throughput code that creates and discards many decks.
+func AcquireDeck() *Deck {
+	d := deckPool.Get().(*Deck)
+	*d = Deck{buf: appendStandard(d.buf[:0])}
+	return d
+}
+
+// ReleaseDeck returns a deck to the pool for reuse by AcquireDeck. The deck,
+// and any slice obtained from it that aliases its storage, must not be used
+// after it has been released.
+func ReleaseDeck(d *Deck) {
+	if d == nil {
+		return
+	}
+	deckPool.Put(d)
+}
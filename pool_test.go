@@ -0,0 +1,55 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestAcquireDeck(t *testing.T) {
+	d := AcquireDeck()
+
+	if d.Size() != 52 {
+		t.Errorf("Expected acquired deck size to be 52, got %d", d.Size())
+	}
+
+	d.Shuffle()
+	d.EnableDealtHistory()
+	d.DealN(10)
+	ReleaseDeck(d)
+
+	d = AcquireDeck()
+	defer ReleaseDeck(d)
+
+	expected := NewDeck().Cards()
+	cards := d.Cards()
+	if len(cards) != len(expected) {
+		t.Fatalf("Expected %d cards, got %d", len(expected), len(cards))
+	}
+	for i := range expected {
+		if cards[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, cards[i])
+		}
+	}
+
+	if len(d.DealtHistory()) != 0 {
+		t.Error("Acquired deck should have no dealt history")
+	}
+
+	// Releasing nil is a no-op
+	ReleaseDeck(nil)
+}
+
+var benchDeck *Deck
+
+func BenchmarkNewDeck(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchDeck = NewDeck()
+	}
+}
+
+func BenchmarkAcquireDeck(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ReleaseDeck(AcquireDeck())
+	}
+}
@@ -0,0 +1,58 @@
+package deck
+
+// GameRanker assigns each card its value in a particular game, so cards can
+// be compared and sorted the way that game orders them
+type GameRanker interface {
+	Value(c Card) int
+}
+
+// PokerRanker values cards by rank with Aces high: Two is 2 and Ace is 14.
+// Suits are ignored.
+type PokerRanker struct{}
+
+// Value returns the card's poker value
+func (PokerRanker) Value(c Card) int {
+	return c.Rank.aceHighValue()
+}
+
+// BlackjackRanker values cards by their blackjack points: number cards at
+// face value, Jack, Queen and King at 10 and Ace at 11. Hands that would bust
+// count Aces as 1, which depends on the rest of the hand and is not reflected
+// here.
+type BlackjackRanker struct{}
+
+// Value returns the card's blackjack points
+func (BlackjackRanker) Value(c Card) int {
+	switch {
+	case c.Rank == Ace:
+		return 11
+	case c.Rank >= Ten:
+		return 10
+	default:
+		return int(c.Rank)
+	}
+}
+
+// TrickRanker values cards for trick-taking games with a trump suit. Within a
+// suit cards rank Aces high; any trump ranks above every non-trump card.
+type TrickRanker struct {
+	Trump Suit
+}
+
+// Value returns the card's value in a trick, with trumps lifted above the
+// other suits
+func (r TrickRanker) Value(c Card) int {
+	v := c.Rank.aceHighValue()
+	if c.Suit == r.Trump {
+		v += 13
+	}
+	return v
+}
+
+// SortByRanker sorts the deck from lowest to highest value according to r.
+// Cards of equal value keep their order.
+func (d *Deck) SortByRanker(r GameRanker) {
+	sortStable(d.cards(), func(a, b Card) bool {
+		return r.Value(a) < r.Value(b)
+	})
+}
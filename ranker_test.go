@@ -0,0 +1,60 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestPokerRanker(t *testing.T) {
+	deck := NewDeckFromCards([]Card{
+		NewCard(Spades, Ace),
+		NewCard(Hearts, Two),
+		NewCard(Clubs, King),
+		NewCard(Diamonds, Ten),
+	})
+	deck.SortByRanker(PokerRanker{})
+
+	if got := EncodePokerNotation(deck.Cards()); got != "2hTdKcAs" {
+		t.Errorf("Expected 2hTdKcAs, got %s", got)
+	}
+}
+
+func TestBlackjackRanker(t *testing.T) {
+	tests := []struct {
+		card     Card
+		expected int
+	}{
+		{NewCard(Spades, Ace), 11},
+		{NewCard(Hearts, King), 10},
+		{NewCard(Clubs, Jack), 10},
+		{NewCard(Diamonds, Ten), 10},
+		{NewCard(Spades, Seven), 7},
+	}
+
+	for _, tt := range tests {
+		if got := (BlackjackRanker{}).Value(tt.card); got != tt.expected {
+			t.Errorf("Expected %v to be worth %d, got %d", tt.card, tt.expected, got)
+		}
+	}
+
+	// Tens and faces tie, so they keep their order
+	deck := NewDeckFromCards([]Card{
+		NewCard(Hearts, King),
+		NewCard(Spades, Ace),
+		NewCard(Diamonds, Ten),
+		NewCard(Spades, Seven),
+	})
+	deck.SortByRanker(BlackjackRanker{})
+	if got := EncodePokerNotation(deck.Cards()); got != "7sKhTdAs" {
+		t.Errorf("Expected 7sKhTdAs, got %s", got)
+	}
+}
+
+func TestTrickRanker(t *testing.T) {
+	r := TrickRanker{Trump: Hearts}
+	if r.Value(NewCard(Hearts, Two)) <= r.Value(NewCard(Spades, Ace)) {
+		t.Error("The lowest trump should beat the highest non-trump")
+	}
+	if r.Value(NewCard(Clubs, Ace)) <= r.Value(NewCard(Clubs, King)) {
+		t.Error("Aces should rank above Kings")
+	}
+}
@@ -0,0 +1,106 @@
+package deck
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// OpKind identifies the kind of a recorded deck Operation
+type OpKind int
+
+const (
+	// OpDeal deals N cards from the top of the deck
+	OpDeal OpKind = iota + 1
+	// OpShuffle shuffles the deck with ShuffleWithSeed(Seed)
+	OpShuffle
+	// OpCut cuts the deck at Position
+	OpCut
+	// OpInsert inserts Card at Position
+	OpInsert
+)
+
+// opKindNames maps each OpKind to its name in encoded operations
+var opKindNames = map[OpKind]string{
+	OpDeal:    "deal",
+	OpShuffle: "shuffle",
+	OpCut:     "cut",
+	OpInsert:  "insert",
+}
+
+// String returns the name of the operation kind
+func (k OpKind) String() string {
+	if name, ok := opKindNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText encodes the operation kind as its name
+func (k OpKind) MarshalText() ([]byte, error) {
+	name, ok := opKindNames[k]
+	if !ok {
+		return nil, errors.New("unknown operation kind")
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes an operation kind from its name
+func (k *OpKind) UnmarshalText(text []byte) error {
+	for kind, name := range opKindNames {
+		if name == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return errors.New("unknown operation kind")
+}
+
+// Operation is a recorded, deterministic deck mutation. Only the fields used
+// by its Kind are meaningful.
+type Operation struct {
+	Kind     OpKind `json:"kind"`
+	N        int    `json:"n,omitempty"`
+	Seed     int64  `json:"seed,omitempty"`
+	Position int    `json:"position,omitempty"`
+	Card     Card   `json:"card,omitzero"`
+}
+
+// Replay applies the operations to the deck in order, so that replaying a
+// game's log against its starting deck reconstructs the final state. It stops
+// at the first operation that fails and returns its error; operations before
+// it remain applied.
+func (d *Deck) Replay(ops []Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Kind {
+		case OpDeal:
+			_, err = d.DealN(op.N)
+		case OpShuffle:
+			d.ShuffleWithSeed(op.Seed)
+		case OpCut:
+			err = d.Cut(op.Position)
+		case OpInsert:
+			err = d.InsertCard(op.Card, op.Position)
+		default:
+			err = errors.New("unknown operation kind")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeOperations encodes a log of operations as JSON
+func EncodeOperations(ops []Operation) ([]byte, error) {
+	return json.Marshal(ops)
+}
+
+// DecodeOperations decodes a log of operations produced by EncodeOperations
+func DecodeOperations(data []byte) ([]Operation, error) {
+	var ops []Operation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
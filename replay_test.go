@@ -0,0 +1,50 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	ops := []Operation{
+		{Kind: OpShuffle, Seed: 99},
+		{Kind: OpDeal, N: 5},
+		{Kind: OpCut, Position: 20},
+		{Kind: OpInsert, Card: NewCard(Hearts, Ace), Position: 3},
+		{Kind: OpShuffle, Seed: 100},
+	}
+
+	// Apply the operations directly
+	expected := NewDeck()
+	expected.ShuffleWithSeed(99)
+	expected.DealN(5)
+	expected.Cut(20)
+	expected.InsertCard(NewCard(Hearts, Ace), 3)
+	expected.ShuffleWithSeed(100)
+
+	data, err := EncodeOperations(ops)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding: %v", err)
+	}
+	decoded, err := DecodeOperations(data)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding: %v", err)
+	}
+
+	deck := NewDeck()
+	if err := deck.Replay(decoded); err != nil {
+		t.Fatalf("Unexpected error replaying: %v", err)
+	}
+	if deck.Hash() != expected.Hash() {
+		t.Error("Expected replay to reconstruct the same deck")
+	}
+
+	if err := NewDeck().Replay([]Operation{{Kind: OpDeal, N: 53}}); err == nil {
+		t.Error("Expected error replaying an impossible deal")
+	}
+	if err := NewDeck().Replay([]Operation{{}}); err == nil {
+		t.Error("Expected error replaying an unknown operation")
+	}
+	if _, err := DecodeOperations([]byte(`[{"kind":"juggle"}]`)); err == nil {
+		t.Error("Expected error decoding an unknown operation kind")
+	}
+}
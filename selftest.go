@@ -0,0 +1,74 @@
+package deck
+
+import (
+	"errors"
+	"math"
+)
+
+// selfTestZ is the standard normal quantile for the self test's significance
+// level of 0.001
+const selfTestZ = 3.09
+
+// ShuffleSelfTest checks that Shuffle places cards uniformly. It shuffles a
+// deck of the same size iterations times and records where a marked card
+// that starts on top lands. If the shuffle is fair each of the n positions is
+// equally likely, so it runs Pearson's chi-squared goodness-of-fit test with
+// n-1 degrees of freedom and returns an error if the statistic exceeds the
+// critical value at a significance level of 0.001. A fair shuffle therefore
+// fails about once in a thousand runs. The critical value is computed with
+// the Wilson-Hilferty approximation.
+//
+// iterations must give an expected count of at least 5 per position, that is
+// at least 5 times the deck size. The deck itself is not modified.
+func (d *Deck) ShuffleSelfTest(iterations int) error {
+	return shuffleSelfTest(d.Size(), iterations, (*Deck).Shuffle)
+}
+
+// shuffleSelfTest runs the self test against an arbitrary shuffle of n cards
+func shuffleSelfTest(n, iterations int, shuffle func(*Deck)) error {
+	if n < 2 {
+		return errors.New("self test requires at least 2 cards")
+	}
+	if iterations < 5*n {
+		return errors.New("self test requires at least 5 iterations per card")
+	}
+
+	marked := NewCard(Spades, Ace)
+	trial := NewEmptyDeck()
+	counts := make([]int, n)
+	for i := 0; i < iterations; i++ {
+		cards := append(trial.buf[:0], marked)
+		for len(cards) < n {
+			cards = append(cards, Card{})
+		}
+		trial.setCards(cards)
+		shuffle(trial)
+
+		for pos, card := range trial.cards() {
+			if card == marked {
+				counts[pos]++
+				break
+			}
+		}
+	}
+
+	expected := float64(iterations) / float64(n)
+	chi := 0.0
+	for _, observed := range counts {
+		diff := float64(observed) - expected
+		chi += diff * diff / expected
+	}
+
+	if chi > chiSquaredCritical(n-1, selfTestZ) {
+		return errors.New("shuffle failed chi-squared self test")
+	}
+	return nil
+}
+
+// chiSquaredCritical approximates the upper critical value of the chi-squared
+// distribution with k degrees of freedom for the normal quantile z, using the
+// Wilson-Hilferty transformation
+func chiSquaredCritical(k int, z float64) float64 {
+	v := 2 / (9 * float64(k))
+	return float64(k) * math.Pow(1-v+z*math.Sqrt(v), 3)
+}
@@ -0,0 +1,53 @@
+package deck
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestShuffleSelfTest(t *testing.T) {
+	// A seeded source keeps the passing case deterministic; the unseeded
+	// Shuffle fails at the test's significance level about once in 1000 runs
+	r := rand.New(rand.NewSource(1))
+	if err := shuffleSelfTest(52, 10000, func(d *Deck) { d.ShuffleWithRand(r) }); err != nil {
+		t.Errorf("Expected a seeded shuffle to pass the self test, got %v", err)
+	}
+
+	// The outcome of the unseeded test is random, but either way the deck
+	// must be untouched
+	deck := NewDeck()
+	_ = deck.ShuffleSelfTest(1000)
+	cards := deck.Cards()
+	for i, card := range NewDeck().Cards() {
+		if cards[i] != card {
+			t.Fatal("Self test should not modify the deck")
+		}
+	}
+
+	if err := deck.ShuffleSelfTest(100); err == nil {
+		t.Error("Expected error with too few iterations")
+	}
+	if err := NewEmptyDeck().ShuffleSelfTest(1000); err == nil {
+		t.Error("Expected error for an empty deck")
+	}
+
+	// Moving the top card one place down is clearly not uniform
+	biased := func(d *Deck) {
+		cards := d.cards()
+		cards[0], cards[1] = cards[1], cards[0]
+	}
+	if err := shuffleSelfTest(10, 1000, biased); err == nil {
+		t.Error("Expected a biased shuffle to fail the self test")
+	}
+}
+
+func TestChiSquaredCritical(t *testing.T) {
+	// Table values at significance level 0.001
+	tests := map[int]float64{9: 27.877, 51: 87.968}
+	for k, expected := range tests {
+		if got := chiSquaredCritical(k, selfTestZ); math.Abs(got-expected) > 0.5 {
+			t.Errorf("Expected critical value near %.3f for %d degrees of freedom, got %.3f", expected, k, got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package deck
+
+import "errors"
+
+// DealReport summarizes many simulated deals of a fresh, shuffled deck. Each
+// slice is indexed by player, in dealing order.
+type DealReport struct {
+	Players   int
+	CardsEach int
+	Trials    int
+
+	// RankCounts[p][r] is the number of trials in which player p was dealt
+	// a hand of rank r
+	RankCounts [][FiveOfAKind + 1]int
+
+	// PairOrBetter is the fraction of trials in which each player was dealt
+	// at least one pair
+	PairOrBetter []float64
+
+	// AverageHighCard is each player's mean highest card value, counting
+	// Ace as 14
+	AverageHighCard []float64
+}
+
+// SimulateDeals shuffles a standard 52-card deck and deals cardsEach cards to
+// each player, one at a time, trials times, and reports how the hands fell.
+// Random numbers are drawn from r, so a seeded *rand.Rand gives a
+// reproducible report.
+func SimulateDeals(players, cardsEach, trials int, r Intner) (DealReport, error) {
+	if players <= 0 {
+		return DealReport{}, errors.New("number of players must be positive")
+	}
+	if trials <= 0 {
+		return DealReport{}, errors.New("number of trials must be positive")
+	}
+	if r == nil {
+		return DealReport{}, errors.New("random source is required")
+	}
+
+	report := DealReport{
+		Players:    players,
+		CardsEach:  cardsEach,
+		Trials:     trials,
+		RankCounts: make([][FiveOfAKind + 1]int, players),
+	}
+	d := NewDeck()
+	highTotals := make([]int, players)
+	for trial := 0; trial < trials; trial++ {
+		d.Reset()
+		d.ShuffleWithRand(r)
+		hands, err := d.dealRoundRobin(players, cardsEach)
+		if err != nil {
+			return DealReport{}, err
+		}
+
+		for p, hand := range hands {
+			report.RankCounts[p][EvaluatePokerHand(hand)]++
+			high := 0
+			for _, card := range hand {
+				if v := card.Rank.aceHighValue(); v > high {
+					high = v
+				}
+			}
+			highTotals[p] += high
+		}
+	}
+
+	report.PairOrBetter = make([]float64, players)
+	report.AverageHighCard = make([]float64, players)
+	for p := range report.RankCounts {
+		paired := trials - report.RankCounts[p][HighCard]
+		report.PairOrBetter[p] = float64(paired) / float64(trials)
+		report.AverageHighCard[p] = float64(highTotals[p]) / float64(trials)
+	}
+	return report, nil
+}
@@ -0,0 +1,53 @@
+package deck
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSimulateDeals(t *testing.T) {
+	report, err := SimulateDeals(4, 5, 2000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(report.RankCounts) != 4 || len(report.PairOrBetter) != 4 || len(report.AverageHighCard) != 4 {
+		t.Fatalf("Expected statistics for 4 players, got %+v", report)
+	}
+
+	for p := 0; p < 4; p++ {
+		total := 0
+		for _, count := range report.RankCounts[p] {
+			total += count
+		}
+		if total != 2000 {
+			t.Errorf("Expected player %d's rank counts to sum to 2000, got %d", p, total)
+		}
+
+		// About 49.9% of five-card hands hold a pair or better
+		if math.Abs(report.PairOrBetter[p]-0.499) > 0.05 {
+			t.Errorf("Expected player %d to pair about half the time, got %.3f", p, report.PairOrBetter[p])
+		}
+
+		if report.AverageHighCard[p] < 10 || report.AverageHighCard[p] > 14 {
+			t.Errorf("Unexpected average high card for player %d: %.2f", p, report.AverageHighCard[p])
+		}
+	}
+
+	// The same seed gives the same report
+	again, _ := SimulateDeals(4, 5, 2000, rand.New(rand.NewSource(1)))
+	if again.RankCounts[0] != report.RankCounts[0] {
+		t.Error("Expected identical reports from the same seed")
+	}
+
+	if _, err := SimulateDeals(11, 5, 10, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("Expected error dealing more cards than the deck holds")
+	}
+	if _, err := SimulateDeals(4, 5, 0, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("Expected error with no trials")
+	}
+	if _, err := SimulateDeals(4, 5, 10, nil); err == nil {
+		t.Error("Expected error without a random source")
+	}
+}
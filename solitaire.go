@@ -0,0 +1,30 @@
+package deck
+
+// Klondike solitaire rules. An empty tableau pile or foundation is passed as
+// the zero Card.
+
+// CanStack reports whether lower may be placed on upper in a Klondike tableau
+// pile: lower must be one rank below upper and of the opposite color. Only a
+// King may be placed on an empty pile.
+func CanStack(lower, upper Card) bool {
+	if !lower.IsValid() {
+		return false
+	}
+	if upper == (Card{}) {
+		return lower.Rank == King
+	}
+	return upper.IsValid() && lower.Color() != upper.Color() && lower.Rank+1 == upper.Rank
+}
+
+// CanFoundation reports whether card may be played on a foundation whose top
+// card is top: it must be the same suit and one rank higher. Only an Ace may
+// start an empty foundation.
+func CanFoundation(card, top Card) bool {
+	if !card.IsValid() {
+		return false
+	}
+	if top == (Card{}) {
+		return card.Rank == Ace
+	}
+	return top.IsValid() && card.Suit == top.Suit && card.Rank == top.Rank+1
+}
@@ -0,0 +1,46 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestCanStack(t *testing.T) {
+	tests := []struct {
+		lower, upper Card
+		expected     bool
+	}{
+		{NewCard(Hearts, Six), NewCard(Spades, Seven), true},
+		{NewCard(Clubs, Six), NewCard(Spades, Seven), false},
+		{NewCard(Hearts, Five), NewCard(Spades, Seven), false},
+		{NewCard(Hearts, Eight), NewCard(Spades, Seven), false},
+		{NewCard(Diamonds, Queen), NewCard(Clubs, King), true},
+		{NewCard(Spades, King), Card{}, true},
+		{NewCard(Spades, Queen), Card{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanStack(tt.lower, tt.upper); got != tt.expected {
+			t.Errorf("Expected CanStack(%v, %v) to be %v", tt.lower, tt.upper, tt.expected)
+		}
+	}
+}
+
+func TestCanFoundation(t *testing.T) {
+	tests := []struct {
+		card, top Card
+		expected  bool
+	}{
+		{NewCard(Hearts, Ace), Card{}, true},
+		{NewCard(Hearts, Two), Card{}, false},
+		{NewCard(Hearts, Two), NewCard(Hearts, Ace), true},
+		{NewCard(Diamonds, Two), NewCard(Hearts, Ace), false},
+		{NewCard(Hearts, Three), NewCard(Hearts, Ace), false},
+		{NewCard(Clubs, King), NewCard(Clubs, Queen), true},
+	}
+
+	for _, tt := range tests {
+		if got := CanFoundation(tt.card, tt.top); got != tt.expected {
+			t.Errorf("Expected CanFoundation(%v, %v) to be %v", tt.card, tt.top, tt.expected)
+		}
+	}
+}
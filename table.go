@@ -0,0 +1,247 @@
+package deck
+
+import "errors"
+
+// street tracks how far a Table's deal has progressed
+type street int
+
+const (
+	streetStart street = iota
+	streetHole
+	streetFlop
+	streetTurn
+	streetRiver
+)
+
+// Table deals a community-card poker hand, such as Texas Hold'em, from a
+// deck: hole cards to each player, then the flop, turn and river to the
+// board, burning a card before each. The deal must proceed in that order.
+type Table struct {
+	deck    *Deck
+	players int
+	street  street
+
+	hands [][]Card
+	board []Card
+	burn  []Card
+	muck  []Card
+}
+
+// NewTable creates a table for the given number of players, dealing from d
+func NewTable(d *Deck, players int) (*Table, error) {
+	if d == nil {
+		return nil, errors.New("table requires a deck")
+	}
+	if players <= 0 {
+		return nil, errors.New("number of players must be positive")
+	}
+	return &Table{deck: d, players: players}, nil
+}
+
+// DealHoleCards deals n hole cards to each player, one card at a time
+func (t *Table) DealHoleCards(n int) error {
+	if t.street != streetStart {
+		return errors.New("hole cards have already been dealt")
+	}
+
+	hands, err := t.deck.dealRoundRobin(t.players, n)
+	if err != nil {
+		return err
+	}
+	t.hands = hands
+	t.street = streetHole
+	return nil
+}
+
+// DealFlop burns a card and deals three cards to the board
+func (t *Table) DealFlop() error {
+	if t.street != streetHole {
+		return errors.New("flop must be dealt after the hole cards")
+	}
+	if err := t.burnAndDeal(3); err != nil {
+		return err
+	}
+	t.street = streetFlop
+	return nil
+}
+
+// DealTurn burns a card and deals the fourth board card
+func (t *Table) DealTurn() error {
+	if t.street != streetFlop {
+		return errors.New("turn must be dealt after the flop")
+	}
+	if err := t.burnAndDeal(1); err != nil {
+		return err
+	}
+	t.street = streetTurn
+	return nil
+}
+
+// DealRiver burns a card and deals the fifth board card
+func (t *Table) DealRiver() error {
+	if t.street != streetTurn {
+		return errors.New("river must be dealt after the turn")
+	}
+	if err := t.burnAndDeal(1); err != nil {
+		return err
+	}
+	t.street = streetRiver
+	return nil
+}
+
+// burnAndDeal burns one card then deals n cards to the board
+func (t *Table) burnAndDeal(n int) error {
+	if t.deck.Size() < n+1 {
+		return errors.New("not enough cards in deck")
+	}
+
+	burned, _ := t.deck.Deal()
+	cards, _ := t.deck.DealN(n)
+	t.burn = append(t.burn, burned)
+	t.board = append(t.board, cards...)
+	return nil
+}
+
+// Fold moves a player's hole cards to the muck
+func (t *Table) Fold(player int) error {
+	if player < 0 || player >= len(t.hands) {
+		return errors.New("invalid player")
+	}
+	if len(t.hands[player]) == 0 {
+		return errors.New("player has no cards")
+	}
+
+	t.muck = append(t.muck, t.hands[player]...)
+	t.hands[player] = nil
+	return nil
+}
+
+// Hands returns a copy of each player's hole cards. Folded players have none.
+func (t *Table) Hands() [][]Card {
+	hands := make([][]Card, len(t.hands))
+	for i, hand := range t.hands {
+		hands[i] = append([]Card(nil), hand...)
+	}
+	return hands
+}
+
+// Board returns a copy of the community cards dealt so far
+func (t *Table) Board() []Card {
+	return append([]Card(nil), t.board...)
+}
+
+// Burned returns a copy of the burned cards
+func (t *Table) Burned() []Card {
+	return append([]Card(nil), t.burn...)
+}
+
+// Muck returns a copy of the folded cards
+func (t *Table) Muck() []Card {
+	return append([]Card(nil), t.muck...)
+}
+
+// BettingRound gives the seat positions for a hand of blinds-based poker,
+// such as Hold'em, relative to the dealer button. Seats are numbered
+// clockwise from 0. It handles positions only, not chips.
+type BettingRound struct {
+	Players int
+	Button  int
+
+	SmallBlind int
+	BigBlind   int
+
+	// DealStart is the first seat dealt to, for use with DealDirectional
+	DealStart int
+
+	// FirstToAct is the first seat to act before the flop, and
+	// FirstToActPostflop the first to act on later streets
+	FirstToAct         int
+	FirstToActPostflop int
+}
+
+// NewBettingRound works out the blinds and order of action for the given
+// number of players and button seat. Normally the two seats after the button
+// post the small and big blinds and the seat after the big blind acts first.
+// Heads-up play is the exception: the button posts the small blind and acts
+// first before the flop, and the big blind acts first after it.
+func NewBettingRound(players, button int) (BettingRound, error) {
+	if players < 2 {
+		return BettingRound{}, errors.New("betting requires at least 2 players")
+	}
+	if button < 0 || button >= players {
+		return BettingRound{}, errors.New("button seat out of range")
+	}
+
+	seat := func(offset int) int { return (button + offset) % players }
+	round := BettingRound{Players: players, Button: button}
+	if players == 2 {
+		round.SmallBlind = button
+		round.BigBlind = seat(1)
+		round.DealStart = seat(1)
+		round.FirstToAct = button
+		round.FirstToActPostflop = seat(1)
+		return round, nil
+	}
+
+	round.SmallBlind = seat(1)
+	round.BigBlind = seat(2)
+	round.DealStart = seat(1)
+	round.FirstToAct = seat(3)
+	round.FirstToActPostflop = seat(1)
+	return round, nil
+}
+
+// studStreets gives the orientation of each of the seven cards in seven-card
+// stud: two down and one up on third street, one up on each of fourth, fifth
+// and sixth streets, and one down on seventh street
+var studStreets = [7]bool{false, false, true, true, true, true, false}
+
+// DealStud deals a full hand of seven-card stud, one card per player per
+// round in street order, without burn cards. Each player ends with three
+// face-down and four face-up cards, each slice in the order dealt. Seven
+// cards per player limits a 52-card deck to seven players.
+func (d *Deck) DealStud(players int) (downCards [][]Card, upCards [][]Card, err error) {
+	if players <= 0 {
+		return nil, nil, errors.New("number of players must be positive")
+	}
+	if players*len(studStreets) > d.Size() {
+		return nil, nil, errors.New("not enough cards in deck")
+	}
+
+	downCards = make([][]Card, players)
+	upCards = make([][]Card, players)
+	for p := 0; p < players; p++ {
+		downCards[p] = make([]Card, 0, 3)
+		upCards[p] = make([]Card, 0, 4)
+	}
+
+	for _, up := range studStreets {
+		for p := 0; p < players; p++ {
+			card, _ := d.Deal()
+			if up {
+				upCards[p] = append(upCards[p], card)
+			} else {
+				downCards[p] = append(downCards[p], card)
+			}
+		}
+	}
+	return downCards, upCards, nil
+}
+
+// Muck collects the cards of every hand not listed in winners into a new
+// deck, in player order, as at the end of a showdown. Winner indices that
+// don't refer to a hand are ignored.
+func Muck(hands [][]Card, winners []int) *Deck {
+	won := make(map[int]bool, len(winners))
+	for _, w := range winners {
+		won[w] = true
+	}
+
+	muck := NewEmptyDeck()
+	for i, hand := range hands {
+		if !won[i] {
+			muck.AddCards(hand)
+		}
+	}
+	return muck
+}
@@ -0,0 +1,174 @@
+package deck
+
+import (
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	deck := NewDeck()
+	deck.Shuffle()
+
+	table, err := NewTable(deck, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error creating table: %v", err)
+	}
+
+	if err := table.DealFlop(); err == nil {
+		t.Error("Expected error dealing the flop before hole cards")
+	}
+
+	if err := table.DealHoleCards(2); err != nil {
+		t.Fatalf("Unexpected error dealing hole cards: %v", err)
+	}
+
+	if err := table.DealTurn(); err == nil {
+		t.Error("Expected error dealing the turn before the flop")
+	}
+
+	if err := table.DealFlop(); err != nil {
+		t.Fatalf("Unexpected error dealing flop: %v", err)
+	}
+	if len(table.Board()) != 3 {
+		t.Errorf("Expected 3 board cards after the flop, got %d", len(table.Board()))
+	}
+
+	if err := table.DealRiver(); err == nil {
+		t.Error("Expected error dealing the river before the turn")
+	}
+
+	if err := table.DealTurn(); err != nil {
+		t.Fatalf("Unexpected error dealing turn: %v", err)
+	}
+	if err := table.DealRiver(); err != nil {
+		t.Fatalf("Unexpected error dealing river: %v", err)
+	}
+
+	if len(table.Board()) != 5 || len(table.Burned()) != 3 {
+		t.Errorf("Expected 5 board and 3 burned cards, got %d and %d", len(table.Board()), len(table.Burned()))
+	}
+
+	for i, hand := range table.Hands() {
+		if len(hand) != 2 {
+			t.Errorf("Expected player %d to have 2 cards, got %d", i, len(hand))
+		}
+	}
+
+	// 3 players * 2 + 3 burns + 5 board
+	if deck.Size() != 52-14 {
+		t.Errorf("Expected %d cards left in deck, got %d", 52-14, deck.Size())
+	}
+
+	if err := table.Fold(1); err != nil {
+		t.Errorf("Unexpected error folding: %v", err)
+	}
+	if len(table.Muck()) != 2 || len(table.Hands()[1]) != 0 {
+		t.Error("Folded hand should move to the muck")
+	}
+	if err := table.Fold(1); err == nil {
+		t.Error("Expected error folding twice")
+	}
+
+	if _, err := NewTable(deck, 0); err == nil {
+		t.Error("Expected error creating table with no players")
+	}
+	if _, err := NewTable(nil, 3); err == nil {
+		t.Error("Expected error creating table without a deck")
+	}
+}
+
+func TestNewBettingRound(t *testing.T) {
+	tests := []struct {
+		players, button int
+		expected        BettingRound
+	}{
+		// Heads-up: the button posts the small blind and acts first preflop
+		{2, 0, BettingRound{Players: 2, Button: 0, SmallBlind: 0, BigBlind: 1, DealStart: 1, FirstToAct: 0, FirstToActPostflop: 1}},
+		{2, 1, BettingRound{Players: 2, Button: 1, SmallBlind: 1, BigBlind: 0, DealStart: 0, FirstToAct: 1, FirstToActPostflop: 0}},
+		// Three-handed the button is first to act preflop
+		{3, 0, BettingRound{Players: 3, Button: 0, SmallBlind: 1, BigBlind: 2, DealStart: 1, FirstToAct: 0, FirstToActPostflop: 1}},
+		{6, 4, BettingRound{Players: 6, Button: 4, SmallBlind: 5, BigBlind: 0, DealStart: 5, FirstToAct: 1, FirstToActPostflop: 5}},
+	}
+
+	for _, tt := range tests {
+		got, err := NewBettingRound(tt.players, tt.button)
+		if err != nil {
+			t.Errorf("Unexpected error for %d players: %v", tt.players, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Expected %+v, got %+v", tt.expected, got)
+		}
+	}
+
+	if _, err := NewBettingRound(1, 0); err == nil {
+		t.Error("Expected error with one player")
+	}
+	if _, err := NewBettingRound(4, 4); err == nil {
+		t.Error("Expected error for a button seat out of range")
+	}
+}
+
+func TestDealStud(t *testing.T) {
+	deck := NewDeck()
+	down, up, err := deck.DealStud(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Cards go A, 2, 3, ... of Spades, alternating between the two players
+	expectedDown := [][]Rank{{Ace, Three, King}, {Two, Four, Ace}}
+	expectedUp := [][]Rank{{Five, Seven, Nine, Jack}, {Six, Eight, Ten, Queen}}
+	for p := 0; p < 2; p++ {
+		if len(down[p]) != 3 || len(up[p]) != 4 {
+			t.Fatalf("Expected 3 down and 4 up cards for player %d, got %d and %d", p, len(down[p]), len(up[p]))
+		}
+		for i, rank := range expectedDown[p] {
+			if down[p][i].Rank != rank {
+				t.Errorf("Player %d down card %d: expected %v, got %v", p, i, rank, down[p][i])
+			}
+		}
+		for i, rank := range expectedUp[p] {
+			if up[p][i].Rank != rank {
+				t.Errorf("Player %d up card %d: expected %v, got %v", p, i, rank, up[p][i])
+			}
+		}
+	}
+	if deck.Size() != 38 {
+		t.Errorf("Expected 38 cards left, got %d", deck.Size())
+	}
+
+	if _, _, err := NewDeck().DealStud(8); err == nil {
+		t.Error("Expected error for eight players")
+	}
+	if _, _, err := NewDeck().DealStud(0); err == nil {
+		t.Error("Expected error with no players")
+	}
+}
+
+func TestMuck(t *testing.T) {
+	hands := [][]Card{
+		{NewCard(Spades, Ace), NewCard(Spades, King)},
+		{NewCard(Hearts, Two), NewCard(Clubs, Seven)},
+		{NewCard(Diamonds, Queen), NewCard(Diamonds, Jack)},
+	}
+
+	muck := Muck(hands, []int{0, 5})
+	cards := muck.Cards()
+	expected := append(append([]Card(nil), hands[1]...), hands[2]...)
+	if len(cards) != len(expected) {
+		t.Fatalf("Expected %d mucked cards, got %d", len(expected), len(cards))
+	}
+	for i := range expected {
+		if cards[i] != expected[i] {
+			t.Errorf("Expected %v at position %d, got %v", expected[i], i, cards[i])
+		}
+	}
+
+	if muck.Contains(NewCard(Spades, Ace)) {
+		t.Error("Winner's cards should not be mucked")
+	}
+
+	if Muck(hands, []int{0, 1, 2}).Size() != 0 {
+		t.Error("Expected an empty muck when every hand wins")
+	}
+}